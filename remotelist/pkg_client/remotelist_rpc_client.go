@@ -17,15 +17,16 @@ func main() {
 
 	var reply bool
 	var reply_i int
+	var reply_v remotelist.Value
 
 	fmt.Println("=== Lista 'compras' ===")
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "compras", Value: 10}, &reply)
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "compras", Value: 20}, &reply)
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "compras", Value: 30}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "compras", Value: remotelist.IntValue(10)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "compras", Value: remotelist.IntValue(20)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "compras", Value: remotelist.IntValue(30)}, &reply)
 
 	fmt.Println("\n=== Lista 'tarefas' ===")
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "tarefas", Value: 100}, &reply)
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "tarefas", Value: 200}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "tarefas", Value: remotelist.IntValue(100)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "tarefas", Value: remotelist.IntValue(200)}, &reply)
 
 	fmt.Println("\n=== Testando Size ===")
 	err = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "compras"}, &reply_i)
@@ -43,18 +44,18 @@ func main() {
 	}
 
 	fmt.Println("\n=== Testando Get ===")
-	err = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "compras", Index: 1}, &reply_i)
+	err = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "compras", Index: 1}, &reply_v)
 	if err != nil {
 		fmt.Println("Erro ao obter elemento:", err)
 	} else {
-		fmt.Printf("Lista 'compras' - Posição 1: %d\n", reply_i)
+		fmt.Printf("Lista 'compras' - Posição 1: %v\n", reply_v)
 	}
 
-	err = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "tarefas", Index: 0}, &reply_i)
+	err = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "tarefas", Index: 0}, &reply_v)
 	if err != nil {
 		fmt.Println("Erro ao obter elemento:", err)
 	} else {
-		fmt.Printf("Lista 'tarefas' - Posição 0: %d\n", reply_i)
+		fmt.Printf("Lista 'tarefas' - Posição 0: %v\n", reply_v)
 	}
 
 	fmt.Println("\n=== Listando Todas as Listas (Discovery) ===")
@@ -66,19 +67,36 @@ func main() {
 		fmt.Printf("Listas disponíveis no servidor: %v\n", listAll.ListNames)
 	}
 
+	fmt.Println("\n=== Identificação por UUID ===")
+	var info remotelist.ListInfo
+	err = client.Call("RemoteList.Info", remotelist.InfoArgs{ListName: "compras"}, &info)
+	if err != nil {
+		fmt.Println("Erro ao obter informações:", err)
+	} else {
+		fmt.Printf("Lista 'compras': UUID %s, criada em %d, %d elementos\n", info.UUID, info.CreatedAtUnix, info.Size)
+
+		var byUUID remotelist.ListInfo
+		err = client.Call("RemoteList.LookupUUID", remotelist.LookupUUIDArgs{UUID: info.UUID}, &byUUID)
+		if err != nil {
+			fmt.Println("Erro ao buscar por UUID:", err)
+		} else {
+			fmt.Printf("UUID %s -> lista '%s'\n", byUUID.UUID, byUUID.Name)
+		}
+	}
+
 	fmt.Println("\n=== Testando Remove ===")
-	err = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "compras"}, &reply_i)
+	err = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "compras"}, &reply_v)
 	if err != nil {
 		fmt.Println("Erro ao remover:", err)
 	} else {
-		fmt.Printf("Lista 'compras' - Removido: %d\n", reply_i)
+		fmt.Printf("Lista 'compras' - Removido: %v\n", reply_v)
 	}
 
-	err = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "tarefas"}, &reply_i)
+	err = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "tarefas"}, &reply_v)
 	if err != nil {
 		fmt.Println("Erro ao remover:", err)
 	} else {
-		fmt.Printf("Lista 'tarefas' - Removido: %d\n", reply_i)
+		fmt.Printf("Lista 'tarefas' - Removido: %v\n", reply_v)
 	}
 
 	fmt.Println("\n=== Tamanhos Finais ===")
@@ -109,11 +127,11 @@ func main() {
 	fmt.Println("\n[TESTE 2] Operacoes sequenciais (3 appends + 2 removes)")
 	fmt.Println("Lista: stress")
 	fmt.Println("Esperado: Size final = 1")
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "stress", Value: 1}, &reply)
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "stress", Value: 2}, &reply)
-	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "stress", Value: 3}, &reply)
-	_ = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "stress"}, &reply_i)
-	_ = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "stress"}, &reply_i)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "stress", Value: remotelist.IntValue(1)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "stress", Value: remotelist.IntValue(2)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "stress", Value: remotelist.IntValue(3)}, &reply)
+	_ = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "stress"}, &reply_v)
+	_ = client.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "stress"}, &reply_v)
 	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "stress"}, &reply_i)
 	fmt.Printf("Resultado: Size = %d\n", reply_i)
 	if reply_i == 1 {
@@ -154,7 +172,7 @@ func main() {
 			for j := 0; j < appendsPerGoroutine; j++ {
 				_ = localClient.Call("RemoteList.Append", remotelist.AppendArgs{
 					ListName: "concurrent_append",
-					Value:    id*100 + j,
+					Value:    remotelist.IntValue(id*100 + j),
 				}, &localReply)
 			}
 		}(i)
@@ -180,7 +198,7 @@ func main() {
 
 	// Preparacao
 	for i := 0; i < 5; i++ {
-		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "read_test", Value: i * 10}, &reply)
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "read_test", Value: remotelist.IntValue(i * 10)}, &reply)
 	}
 
 	successCount := 0
@@ -200,10 +218,10 @@ func main() {
 			}
 			defer localClient.Close()
 
-			var localReplyI int
-			err = localClient.Call("RemoteList.Get", remotelist.GetArgs{ListName: "read_test", Index: 2}, &localReplyI)
+			var localReply remotelist.Value
+			err = localClient.Call("RemoteList.Get", remotelist.GetArgs{ListName: "read_test", Index: 2}, &localReply)
 			mu.Lock()
-			if err == nil && localReplyI == 20 {
+			if err == nil && localReply == remotelist.IntValue(20) {
 				successCount++
 			} else {
 				errorCount++
@@ -228,7 +246,7 @@ func main() {
 
 	// Preparacao inicial
 	for i := 0; i < 3; i++ {
-		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "mixed_ops", Value: i}, &reply)
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "mixed_ops", Value: remotelist.IntValue(i)}, &reply)
 	}
 
 	appendSuccess := 0
@@ -252,7 +270,7 @@ func main() {
 			var localReply bool
 			err = localClient.Call("RemoteList.Append", remotelist.AppendArgs{
 				ListName: "mixed_ops",
-				Value:    100 + id,
+				Value:    remotelist.IntValue(100 + id),
 			}, &localReply)
 			mu.Lock()
 			if err == nil {
@@ -279,8 +297,8 @@ func main() {
 			}
 			defer localClient.Close()
 
-			var localReplyI int
-			err = localClient.Call("RemoteList.Get", remotelist.GetArgs{ListName: "mixed_ops", Index: 0}, &localReplyI)
+			var localReply remotelist.Value
+			err = localClient.Call("RemoteList.Get", remotelist.GetArgs{ListName: "mixed_ops", Index: 0}, &localReply)
 			mu.Lock()
 			if err == nil {
 				getSuccess++
@@ -310,7 +328,7 @@ func main() {
 
 	// Preparacao inicial
 	for i := 0; i < 5; i++ {
-		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "append_remove_race", Value: i}, &reply)
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "append_remove_race", Value: remotelist.IntValue(i)}, &reply)
 	}
 
 	appendOps := 0
@@ -332,7 +350,7 @@ func main() {
 			for j := 0; j < 3; j++ {
 				err = localClient.Call("RemoteList.Append", remotelist.AppendArgs{
 					ListName: "append_remove_race",
-					Value:    id*10 + j,
+					Value:    remotelist.IntValue(id*10 + j),
 				}, &localReply)
 				if err == nil {
 					mu.Lock()
@@ -356,9 +374,9 @@ func main() {
 			}
 			defer localClient.Close()
 
-			var localReplyI int
+			var localReply remotelist.Value
 			for j := 0; j < 3; j++ {
-				err = localClient.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "append_remove_race"}, &localReplyI)
+				err = localClient.Call("RemoteList.Remove", remotelist.RemoveArgs{ListName: "append_remove_race"}, &localReply)
 				mu.Lock()
 				if err == nil {
 					removeOps++
@@ -386,6 +404,559 @@ func main() {
 		fmt.Println("Status: ATENCAO - Size final nao coincide com esperado (pode haver race conditions ou removes de lista vazia)")
 	}
 
+	// Teste 7: Rename, Clear e Delete
+	fmt.Println("\n[TESTE 7] Rename, Clear e Delete")
+	fmt.Println("Lista: temporaria -> renomeada")
+	fmt.Println("Esperado: UUID mantido apos rename, size 0 apos clear, lista inexistente apos delete")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "renomeada"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "temporaria", Value: remotelist.IntValue(1)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "temporaria", Value: remotelist.IntValue(2)}, &reply)
+
+	var before, after remotelist.ListInfo
+	_ = client.Call("RemoteList.Info", remotelist.InfoArgs{ListName: "temporaria"}, &before)
+	errRename := client.Call("RemoteList.RenameList", remotelist.RenameListArgs{ListName: "temporaria", NewName: "renomeada"}, &reply)
+	_ = client.Call("RemoteList.Info", remotelist.InfoArgs{ListName: "renomeada"}, &after)
+	errClear := client.Call("RemoteList.Clear", remotelist.ClearArgs{ListName: "renomeada"}, &reply)
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "renomeada"}, &reply_i)
+	sizeAfterClear := reply_i
+	errDelete := client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "renomeada"}, &reply)
+	errInfo := client.Call("RemoteList.Info", remotelist.InfoArgs{ListName: "renomeada"}, &after)
+
+	fmt.Printf("Resultado: UUID antes = %s | UUID depois = %s | Size apos clear = %d | Info apos delete = %v\n",
+		before.UUID, after.UUID, sizeAfterClear, errInfo)
+	if errRename == nil && errClear == nil && errDelete == nil && before.UUID == after.UUID && sizeAfterClear == 0 && errInfo != nil {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 8: Insert, Set e RemoveAt
+	fmt.Println("\n[TESTE 8] Insert, Set e RemoveAt")
+	fmt.Println("Lista: posicional")
+	fmt.Println("Esperado: [10 20 30] -> Insert(1, 15) -> Set(0, 5) -> RemoveAt(2) = [5 15 30]")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "posicional"}, &reply) // limpa execucoes anteriores
+	for _, v := range []int{10, 20, 30} {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "posicional", Value: remotelist.IntValue(v)}, &reply)
+	}
+	errInsert := client.Call("RemoteList.Insert", remotelist.InsertArgs{ListName: "posicional", Index: 1, Value: remotelist.IntValue(15)}, &reply)
+	errSet := client.Call("RemoteList.Set", remotelist.SetArgs{ListName: "posicional", Index: 0, Value: remotelist.IntValue(5)}, &reply_v)
+	errRemoveAt := client.Call("RemoteList.RemoveAt", remotelist.RemoveAtArgs{ListName: "posicional", Index: 2}, &reply_v)
+	removedAt := reply_v
+	errBounds := client.Call("RemoteList.Insert", remotelist.InsertArgs{ListName: "posicional", Index: 10, Value: remotelist.IntValue(1)}, &reply)
+
+	var values []remotelist.Value
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "posicional"}, &reply_i)
+	size := reply_i
+	for i := 0; i < size; i++ {
+		_ = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "posicional", Index: i}, &reply_v)
+		values = append(values, reply_v)
+	}
+
+	fmt.Printf("Resultado: lista = %v | removido = %v | insert fora dos limites = %v\n", values, removedAt, errBounds)
+	if errInsert == nil && errSet == nil && errRemoveAt == nil && errBounds != nil &&
+		fmt.Sprint(values) == "[5 15 30]" && removedAt == remotelist.IntValue(20) {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 9: Fila FIFO com PushFront e PopFront
+	fmt.Println("\n[TESTE 9] Fila FIFO com Append e PopFront")
+	fmt.Println("Lista: fila")
+	fmt.Println("Esperado: PushFront(0) + Append(1..3) -> PopFront retorna 0, 1, 2, 3 e depois erro de lista vazia")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "fila"}, &reply) // limpa execucoes anteriores
+	for i := 1; i <= 3; i++ {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "fila", Value: remotelist.IntValue(i)}, &reply)
+	}
+	errPush := client.Call("RemoteList.PushFront", remotelist.PushFrontArgs{ListName: "fila", Value: remotelist.IntValue(0)}, &reply)
+
+	var popped []remotelist.Value
+	for i := 0; i < 4; i++ {
+		err = client.Call("RemoteList.PopFront", remotelist.PopFrontArgs{ListName: "fila"}, &reply_v)
+		if err == nil {
+			popped = append(popped, reply_v)
+		}
+	}
+	errEmpty := client.Call("RemoteList.PopFront", remotelist.PopFrontArgs{ListName: "fila"}, &reply_v)
+
+	fmt.Printf("Resultado: ordem = %v | PopFront em lista vazia = %v\n", popped, errEmpty)
+	if errPush == nil && fmt.Sprint(popped) == "[0 1 2 3]" && errEmpty != nil {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 10: PopFront bloqueante
+	fmt.Println("\n[TESTE 10] BlockingPopFront com timeout")
+	fmt.Println("Lista: fila_bloqueante")
+	fmt.Println("Esperado: consumidor recebe o valor adicionado 200ms depois; nova espera de 300ms termina em timeout")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "fila_bloqueante"}, &reply) // limpa execucoes anteriores
+
+	consumed := make(chan error, 1)
+	var consumedValue remotelist.Value
+	go func() {
+		consumerClient, err := rpc.Dial("tcp", ":5000")
+		if err != nil {
+			consumed <- err
+			return
+		}
+		defer consumerClient.Close()
+		consumed <- consumerClient.Call("RemoteList.BlockingPopFront",
+			remotelist.BlockingPopFrontArgs{ListName: "fila_bloqueante", Timeout: 5 * time.Second}, &consumedValue)
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "fila_bloqueante", Value: remotelist.IntValue(42)}, &reply)
+	errConsumed := <-consumed
+
+	start := time.Now()
+	errTimeout := client.Call("RemoteList.BlockingPopFront",
+		remotelist.BlockingPopFrontArgs{ListName: "fila_bloqueante", Timeout: 300 * time.Millisecond}, &reply_v)
+	waited := time.Since(start)
+
+	fmt.Printf("Resultado: valor recebido = %v (%v) | espera vazia = %v apos %v\n",
+		consumedValue, errConsumed, errTimeout, waited.Round(time.Millisecond))
+	if errConsumed == nil && consumedValue == remotelist.IntValue(42) && errTimeout != nil && waited >= 300*time.Millisecond {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 11: Leitura por intervalo e paginada
+	fmt.Println("\n[TESTE 11] GetRange e GetAll paginado")
+	fmt.Println("Lista: intervalo (0..24)")
+	fmt.Println("Esperado: GetRange(-3, -1) = [22 23]; GetAll em paginas de 10 le os 25 elementos em 3 chamadas")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "intervalo"}, &reply) // limpa execucoes anteriores
+	for i := 0; i < 25; i++ {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "intervalo", Value: remotelist.IntValue(i)}, &reply)
+	}
+
+	var rangeReply remotelist.RangeReply
+	errRange := client.Call("RemoteList.GetRange", remotelist.GetRangeArgs{ListName: "intervalo", Start: -3, End: -1}, &rangeReply)
+	rangeValues := rangeReply.Values
+
+	var allValues []remotelist.Value
+	pages := 0
+	cursor := ""
+	var errPage error
+	for {
+		var page remotelist.RangeReply
+		errPage = client.Call("RemoteList.GetAll", remotelist.GetAllArgs{ListName: "intervalo", Cursor: cursor, Limit: 10}, &page)
+		if errPage != nil {
+			break
+		}
+		pages++
+		allValues = append(allValues, page.Values...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	fmt.Printf("Resultado: GetRange = %v | GetAll = %d elementos em %d paginas (versao %d)\n",
+		rangeValues, len(allValues), pages, rangeReply.Version)
+	if errRange == nil && errPage == nil && fmt.Sprint(rangeValues) == "[22 23]" && len(allValues) == 25 && pages == 3 {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 12: Batch
+	fmt.Println("\n[TESTE 12] Batch com operacoes mistas")
+	fmt.Println("Lista: lote")
+	fmt.Println("Esperado: 50 appends + Size + Get em uma chamada; Remove de lista inexistente falha sem afetar as demais")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "lote"}, &reply) // limpa execucoes anteriores
+
+	var ops []remotelist.BatchOp
+	for i := 0; i < 50; i++ {
+		ops = append(ops, remotelist.BatchOp{Op: "Append", ListName: "lote", Value: remotelist.IntValue(i)})
+	}
+	ops = append(ops,
+		remotelist.BatchOp{Op: "Remove", ListName: "lista_inexistente"},
+		remotelist.BatchOp{Op: "Size", ListName: "lote"},
+		remotelist.BatchOp{Op: "Get", ListName: "lote", Index: 49},
+	)
+
+	var batchReply remotelist.BatchReply
+	start = time.Now()
+	errBatch := client.Call("RemoteList.Batch", remotelist.BatchArgs{Ops: ops}, &batchReply)
+	elapsed := time.Since(start)
+
+	if errBatch == nil && len(batchReply.Results) == len(ops) {
+		results := batchReply.Results[50:]
+		fmt.Printf("Resultado: Remove = %q | Size = %v | Get(49) = %v | tempo = %v\n",
+			results[0].Error, results[1].Value, results[2].Value, elapsed.Round(time.Microsecond))
+		if results[0].Error != "" && results[1].Value == remotelist.IntValue(50) && results[2].Value == remotelist.IntValue(49) {
+			fmt.Println("Status: PASSOU")
+		} else {
+			fmt.Println("Status: FALHOU")
+		}
+	} else {
+		fmt.Printf("Resultado: erro = %v\n", errBatch)
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 13: Transacao entre listas
+	fmt.Println("\n[TESTE 13] Transacao atomica entre listas")
+	fmt.Println("Listas: pendentes -> concluidas")
+	fmt.Println("Esperado: transacao valida move o item; transacao com operacao invalida nao altera nenhuma lista")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "pendentes"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "concluidas"}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "pendentes", Value: remotelist.IntValue(7)}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "pendentes", Value: remotelist.IntValue(8)}, &reply)
+
+	var txReply remotelist.BatchReply
+	errTx := client.Call("RemoteList.Transaction", remotelist.TransactionArgs{Ops: []remotelist.BatchOp{
+		{Op: "RemoveAt", ListName: "pendentes", Index: 0},
+		{Op: "Append", ListName: "concluidas", Value: remotelist.IntValue(7)},
+	}}, &txReply)
+
+	errAborted := client.Call("RemoteList.Transaction", remotelist.TransactionArgs{Ops: []remotelist.BatchOp{
+		{Op: "Remove", ListName: "pendentes"},
+		{Op: "Get", ListName: "concluidas", Index: 5}, // fora dos limites: aborta
+	}}, &txReply)
+
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "pendentes"}, &reply_i)
+	pendentes := reply_i
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "concluidas"}, &reply_i)
+	concluidas := reply_i
+
+	fmt.Printf("Resultado: pendentes = %d | concluidas = %d | transacao abortada = %v\n", pendentes, concluidas, errAborted)
+	if errTx == nil && errAborted != nil && pendentes == 1 && concluidas == 1 {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 14: Move (fila confiavel)
+	fmt.Println("\n[TESTE 14] Move entre listas (fila confiavel)")
+	fmt.Println("Listas: jobs -> processando_1")
+	fmt.Println("Esperado: o primeiro job sai de jobs e vai para processando_1 em uma unica operacao")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "jobs"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "processando_1"}, &reply)
+	for i := 101; i <= 103; i++ {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "jobs", Value: remotelist.IntValue(i)}, &reply)
+	}
+
+	var moveReply remotelist.MoveReply
+	errMove := client.Call("RemoteList.Move", remotelist.MoveArgs{Source: "jobs", Destination: "processando_1", ToEnd: true}, &moveReply)
+	moved := moveReply.Value
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "jobs"}, &reply_i)
+	jobs := reply_i
+	_ = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "processando_1", Index: 0}, &reply_v)
+	processing := reply_v
+
+	fmt.Printf("Resultado: movido = %v | jobs restantes = %d | processando_1[0] = %v\n", moved, jobs, processing)
+	if errMove == nil && moveReply.Moved && moved == remotelist.IntValue(101) && jobs == 2 && processing == moved {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 15: Concorrencia otimista
+	fmt.Println("\n[TESTE 15] Escrita condicional por versao")
+	fmt.Println("Lista: contador")
+	fmt.Println("Esperado: 10 goroutines incrementam contador[0] com SetIfVersion, repetindo nos conflitos; valor final 10")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "contador"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "contador", Value: remotelist.IntValue(0)}, &reply)
+
+	conflicts := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			localClient, err := rpc.Dial("tcp", ":5000")
+			if err != nil {
+				return
+			}
+			defer localClient.Close()
+
+			for {
+				var current, written remotelist.VersionedValue
+				err = localClient.Call("RemoteList.GetWithVersion", remotelist.GetArgs{ListName: "contador", Index: 0}, &current)
+				if err != nil {
+					return
+				}
+				n, _ := current.Value.Int()
+				err = localClient.Call("RemoteList.SetIfVersion", remotelist.SetIfVersionArgs{
+					ListName: "contador", Index: 0, Value: remotelist.IntValue(n + 1), ExpectedVersion: current.Version}, &written)
+				if err == nil {
+					return
+				}
+				if err.Error() != remotelist.ErrVersionConflict.Error() {
+					return
+				}
+				mu.Lock()
+				conflicts++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	_ = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "contador", Index: 0}, &reply_v)
+	fmt.Printf("Resultado: contador = %v | conflitos detectados = %d\n", reply_v, conflicts)
+	if reply_v == remotelist.IntValue(10) {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 16: Contadores com AddAt e CompareAndSet
+	fmt.Println("\n[TESTE 16] AddAt concorrente e CompareAndSet")
+	fmt.Println("Lista: buckets")
+	fmt.Println("Esperado: 20 goroutines fazem AddAt(0, +5) -> 100; CompareAndSet(100 -> 0) troca, CompareAndSet(100 -> 1) nao")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "buckets"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "buckets", Value: remotelist.IntValue(0)}, &reply)
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			localClient, err := rpc.Dial("tcp", ":5000")
+			if err != nil {
+				return
+			}
+			defer localClient.Close()
+			var newValue int
+			_ = localClient.Call("RemoteList.AddAt", remotelist.AddAtArgs{ListName: "buckets", Index: 0, Delta: 5}, &newValue)
+		}()
+	}
+	wg.Wait()
+
+	_ = client.Call("RemoteList.Get", remotelist.GetArgs{ListName: "buckets", Index: 0}, &reply_v)
+	total := reply_v
+	var casFirst, casSecond remotelist.CompareAndSetReply
+	errCAS := client.Call("RemoteList.CompareAndSet", remotelist.CompareAndSetArgs{ListName: "buckets", Index: 0, Expected: remotelist.IntValue(100), New: remotelist.IntValue(0)}, &casFirst)
+	_ = client.Call("RemoteList.CompareAndSet", remotelist.CompareAndSetArgs{ListName: "buckets", Index: 0, Expected: remotelist.IntValue(100), New: remotelist.IntValue(1)}, &casSecond)
+
+	fmt.Printf("Resultado: total = %v | primeiro CAS = %v | segundo CAS = %v (atual %v)\n",
+		total, casFirst.Swapped, casSecond.Swapped, casSecond.Current)
+	if errCAS == nil && total == remotelist.IntValue(100) && casFirst.Swapped && !casSecond.Swapped && casSecond.Current == remotelist.IntValue(0) {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 17: Tipos de valor
+	fmt.Println("\n[TESTE 17] Lista com valores de tipos diferentes")
+	fmt.Println("Lista: mista")
+	fmt.Println("Esperado: int, float, string, bytes e JSON voltam com o mesmo tipo e conteudo; AddAt em string falha")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "mista"}, &reply) // limpa execucoes anteriores
+	doc, _ := remotelist.JSONValue([]byte(`{"id": 7, "tags": ["a", "b"]}`))
+	sent := []remotelist.Value{
+		remotelist.IntValue(-3),
+		remotelist.FloatValue(2.5),
+		remotelist.StringValue("olá"),
+		remotelist.BytesValue([]byte{0, 1, 255}),
+		doc,
+	}
+	for _, v := range sent {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "mista", Value: v}, &reply)
+	}
+
+	var mixedReply remotelist.RangeReply
+	errMixed := client.Call("RemoteList.GetRange", remotelist.GetRangeArgs{ListName: "mista", ToEnd: true}, &mixedReply)
+	var addReply int
+	errAddString := client.Call("RemoteList.AddAt", remotelist.AddAtArgs{ListName: "mista", Index: 2, Delta: 1}, &addReply)
+
+	var kinds []string
+	for _, v := range mixedReply.Values {
+		kinds = append(kinds, v.Kind().String())
+	}
+	fmt.Printf("Resultado: valores = %v | tipos = %v | AddAt em string = %v\n", mixedReply.Values, kinds, errAddString)
+	if errMixed == nil && errAddString != nil && fmt.Sprint(mixedReply.Values) == fmt.Sprint(sent) &&
+		len(mixedReply.Values) == len(sent) && mixedReply.Values[4] == doc {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 18: TTL
+	fmt.Println("\n[TESTE 18] Expiracao de elementos e de listas (TTL)")
+	fmt.Println("Listas: sessao, sessao_temporaria")
+	fmt.Println("Esperado: elemento com TTL de 200ms some da lista; lista com TTL de 200ms e apagada")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "sessao"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "sessao_temporaria"}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "sessao", Value: remotelist.StringValue("permanente")}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "sessao", Value: remotelist.StringValue("temporario"), TTL: 200 * time.Millisecond}, &reply)
+	_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "sessao_temporaria", Value: remotelist.IntValue(1)}, &reply)
+	errTTL := client.Call("RemoteList.SetTTL", remotelist.SetTTLArgs{ListName: "sessao_temporaria", TTL: 200 * time.Millisecond}, &reply)
+
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "sessao"}, &reply_i)
+	sizeBefore := reply_i
+	time.Sleep(300 * time.Millisecond)
+	_ = client.Call("RemoteList.Size", remotelist.SizeArgs{ListName: "sessao"}, &reply_i)
+	sizeAfter := reply_i
+	var expiredInfo remotelist.ListInfo
+	errExpired := client.Call("RemoteList.Info", remotelist.InfoArgs{ListName: "sessao_temporaria"}, &expiredInfo)
+
+	fmt.Printf("Resultado: sessao = %d -> %d elementos | Info(sessao_temporaria) = %v\n", sizeBefore, sizeAfter, errExpired)
+	if errTTL == nil && sizeBefore == 2 && sizeAfter == 1 && errExpired != nil {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 19: Listas com limite
+	fmt.Println("\n[TESTE 19] Listas com limite de tamanho")
+	fmt.Println("Listas: ultimas_leituras (drop_oldest), limitada (reject)")
+	fmt.Println("Esperado: limite 3 com drop_oldest guarda as 3 ultimas de 10 leituras; com reject o 3o Append falha")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "ultimas_leituras"}, &reply) // limpa execucoes anteriores
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "limitada"}, &reply)
+	errCap := client.Call("RemoteList.SetCap", remotelist.SetCapArgs{ListName: "ultimas_leituras", MaxLength: 3, Policy: remotelist.CapDropOldest}, &reply)
+	for i := 1; i <= 10; i++ {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "ultimas_leituras", Value: remotelist.IntValue(i)}, &reply)
+	}
+	var readings remotelist.RangeReply
+	_ = client.Call("RemoteList.GetRange", remotelist.GetRangeArgs{ListName: "ultimas_leituras", ToEnd: true}, &readings)
+
+	_ = client.Call("RemoteList.SetCap", remotelist.SetCapArgs{ListName: "limitada", MaxLength: 2, Policy: remotelist.CapReject}, &reply)
+	var errFull error
+	for i := 1; i <= 3; i++ {
+		errFull = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "limitada", Value: remotelist.IntValue(i)}, &reply)
+	}
+
+	fmt.Printf("Resultado: ultimas_leituras = %v | 3o Append em limitada = %v\n", readings.Values, errFull)
+	if errCap == nil && fmt.Sprint(readings.Values) == "[8 9 10]" && errFull != nil && errFull.Error() == remotelist.ErrListFull.Error() {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 20: Agregacoes no servidor
+	fmt.Println("\n[TESTE 20] Aggregate (sum, min, max, mean, median, count_distinct)")
+	fmt.Println("Lista: medicoes = [5 1 4 1 9 2]")
+	fmt.Println("Esperado: sum 22, min 1, max 9, median 3, count_distinct 5; sum dos 3 ultimos = 12")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "medicoes"}, &reply) // limpa execucoes anteriores
+	for _, v := range []int{5, 1, 4, 1, 9, 2} {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "medicoes", Value: remotelist.IntValue(v)}, &reply)
+	}
+
+	var aggReply, lastReply remotelist.AggregateReply
+	errAgg := client.Call("RemoteList.Aggregate", remotelist.AggregateArgs{ListName: "medicoes"}, &aggReply)
+	errLast := client.Call("RemoteList.Aggregate", remotelist.AggregateArgs{
+		ListName: "medicoes", Ops: []string{"sum"}, Range: true, Start: -3, ToEnd: true}, &lastReply)
+
+	agg := aggReply.Results
+	fmt.Printf("Resultado: %v | sum dos 3 ultimos = %v\n", agg, lastReply.Results["sum"])
+	if errAgg == nil && errLast == nil && agg["sum"] == remotelist.IntValue(22) && agg["min"] == remotelist.IntValue(1) &&
+		agg["max"] == remotelist.IntValue(9) && agg["median"] == remotelist.FloatValue(3) &&
+		agg["count_distinct"] == remotelist.IntValue(5) && lastReply.Results["sum"] == remotelist.IntValue(12) {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 21: Busca por valor
+	fmt.Println("\n[TESTE 21] IndexOf, LastIndexOf, Contains e Count")
+	fmt.Println("Lista: fila_jobs = [\"job-1\" \"job-2\" \"job-1\"], com indice de valores")
+	fmt.Println("Esperado: IndexOf = 0, IndexOf a partir de 1 = 2, LastIndexOf = 2, Count = 2, Contains(job-3) = false")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "fila_jobs"}, &reply) // limpa execucoes anteriores
+	for _, job := range []string{"job-1", "job-2", "job-1"} {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "fila_jobs", Value: remotelist.StringValue(job)}, &reply)
+	}
+	errIndex := client.Call("RemoteList.SetValueIndex", remotelist.SetValueIndexArgs{ListName: "fila_jobs", Enabled: true}, &reply)
+
+	job1 := remotelist.StringValue("job-1")
+	var first, next, last, count int
+	var hasJob3 bool
+	_ = client.Call("RemoteList.IndexOf", remotelist.IndexOfArgs{ListName: "fila_jobs", Value: job1}, &first)
+	_ = client.Call("RemoteList.IndexOf", remotelist.IndexOfArgs{ListName: "fila_jobs", Value: job1, FromIndex: 1}, &next)
+	_ = client.Call("RemoteList.LastIndexOf", remotelist.LastIndexOfArgs{ListName: "fila_jobs", Value: job1}, &last)
+	_ = client.Call("RemoteList.Count", remotelist.CountArgs{ListName: "fila_jobs", Value: job1}, &count)
+	_ = client.Call("RemoteList.Contains", remotelist.ContainsArgs{ListName: "fila_jobs", Value: remotelist.StringValue("job-3")}, &hasJob3)
+
+	fmt.Printf("Resultado: IndexOf = %d | a partir de 1 = %d | LastIndexOf = %d | Count = %d | Contains(job-3) = %v\n",
+		first, next, last, count, hasJob3)
+	if errIndex == nil && first == 0 && next == 2 && last == 2 && count == 2 && !hasJob3 {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 22: Transformacoes
+	fmt.Println("\n[TESTE 22] Sort, Reverse, Dedupe e Trim")
+	fmt.Println("Lista: notas = [7 3 9 3 5 7]")
+	fmt.Println("Esperado: Dedupe remove 2 -> [7 3 9 5]; Sort -> [3 5 7 9]; Reverse -> [9 7 5 3]; Trim(1, -1) remove 2 -> [7 5]")
+
+	_ = client.Call("RemoteList.DeleteList", remotelist.DeleteListArgs{ListName: "notas"}, &reply) // limpa execucoes anteriores
+	for _, nota := range []int{7, 3, 9, 3, 5, 7} {
+		_ = client.Call("RemoteList.Append", remotelist.AppendArgs{ListName: "notas", Value: remotelist.IntValue(nota)}, &reply)
+	}
+
+	var duplicates, trimmed int
+	errDedupe := client.Call("RemoteList.Dedupe", remotelist.DedupeArgs{ListName: "notas"}, &duplicates)
+	var sorted remotelist.RangeReply
+	errSort := client.Call("RemoteList.Sort", remotelist.SortArgs{ListName: "notas"}, &reply)
+	_ = client.Call("RemoteList.GetRange", remotelist.GetRangeArgs{ListName: "notas", ToEnd: true}, &sorted)
+	errReverse := client.Call("RemoteList.Reverse", remotelist.ReverseArgs{ListName: "notas"}, &reply)
+	errTrim := client.Call("RemoteList.Trim", remotelist.TrimArgs{ListName: "notas", Start: 1, End: -1}, &trimmed)
+	var notas remotelist.RangeReply
+	_ = client.Call("RemoteList.GetRange", remotelist.GetRangeArgs{ListName: "notas", ToEnd: true}, &notas)
+
+	fmt.Printf("Resultado: Dedupe removeu %d | Sort = %v | Trim removeu %d -> %v\n", duplicates, sorted.Values, trimmed, notas.Values)
+	if errDedupe == nil && errSort == nil && errReverse == nil && errTrim == nil &&
+		duplicates == 2 && fmt.Sprint(sorted.Values) == "[3 5 7 9]" && trimmed == 2 && fmt.Sprint(notas.Values) == "[7 5]" {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
+	// Teste 23: Conjunto ordenado
+	fmt.Println("\n[TESTE 23] Conjunto ordenado (placar)")
+	fmt.Println("Conjunto: placar = {ana: 50, bia: 80, caio: 65}; depois ana += 40")
+	fmt.Println("Esperado: ana = 90; top 2 = [ana bia]; rank de caio (decrescente) = 2; score entre 60 e 85 = [caio bia]")
+
+	_ = client.Call("RemoteList.DeleteSortedSet", remotelist.DeleteSortedSetArgs{SetName: "placar"}, &reply) // limpa execucoes anteriores
+	var score float64
+	for _, jogador := range []remotelist.SortedSetMember{
+		{Member: remotelist.StringValue("ana"), Score: 50},
+		{Member: remotelist.StringValue("bia"), Score: 80},
+		{Member: remotelist.StringValue("caio"), Score: 65},
+	} {
+		_ = client.Call("RemoteList.SortedSetAdd", remotelist.SortedSetAddArgs{SetName: "placar", Member: jogador.Member, Score: jogador.Score}, &score)
+	}
+	errIncrement := client.Call("RemoteList.SortedSetAdd", remotelist.SortedSetAddArgs{SetName: "placar", Member: remotelist.StringValue("ana"), Score: 40, Increment: true}, &score)
+
+	var top, middle remotelist.SortedSetRangeReply
+	var caioRank int
+	errTop := client.Call("RemoteList.SortedSetRange", remotelist.SortedSetRangeArgs{SetName: "placar", Start: 0, End: 2, Descending: true}, &top)
+	_ = client.Call("RemoteList.SortedSetRank", remotelist.SortedSetRankArgs{SetName: "placar", Member: remotelist.StringValue("caio"), Descending: true}, &caioRank)
+	errByScore := client.Call("RemoteList.SortedSetRangeByScore", remotelist.SortedSetRangeByScoreArgs{SetName: "placar", Min: 60, Max: 85}, &middle)
+
+	memberNames := func(members []remotelist.SortedSetMember) []string {
+		var names []string
+		for _, m := range members {
+			name, _ := m.Member.Str()
+			names = append(names, name)
+		}
+		return names
+	}
+	fmt.Printf("Resultado: ana = %v | top 2 = %v | rank de caio = %d | score entre 60 e 85 = %v\n",
+		score, memberNames(top.Members), caioRank, memberNames(middle.Members))
+	if errIncrement == nil && errTop == nil && errByScore == nil && score == 90 &&
+		fmt.Sprint(memberNames(top.Members)) == "[ana bia]" && caioRank == 2 && fmt.Sprint(memberNames(middle.Members)) == "[caio bia]" {
+		fmt.Println("Status: PASSOU")
+	} else {
+		fmt.Println("Status: FALHOU")
+	}
+
 	fmt.Println("\n========================================")
 	fmt.Println("TESTES CONCLUIDOS")
 	fmt.Println("========================================")
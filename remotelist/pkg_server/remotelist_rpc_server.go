@@ -1,27 +1,71 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"ifpb/remotelist/pkg_structs"
 	"net"
-	"net/rpc"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+// Prazo para as chamadas em andamento terminarem no encerramento
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	list := remotelist.NewRemoteList()
-	rpcs := rpc.NewServer()
-	rpcs.Register(list)
-	l, e := net.Listen("tcp", "[localhost]:5000")
-	defer l.Close()
+	// Configuração: padrão < arquivo (-config) < variáveis REMOTELIST_* < flags
+	config, err := remotelist.LoadConfig(os.Args[1:])
+	if err == flag.ErrHelp {
+		os.Exit(0)
+	}
+	if err != nil {
+		fmt.Println("erro de configuração:", err)
+		os.Exit(2)
+	}
+
+	list, err := remotelist.NewRemoteListWithConfig(config)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	server, err := remotelist.NewServer(list)
+	if err != nil {
+		fmt.Println("erro ao registrar RPC:", err)
+		os.Exit(1)
+	}
+	l, e := net.Listen("tcp", config.ListenAddress)
 	if e != nil {
 		fmt.Println("listen error:", e)
+		os.Exit(1)
 	}
-	for {
-		conn, err := l.Accept()
-		if err == nil {
-			go rpcs.ServeConn(conn) //goroutines permite multiplos clientes se conectarem
-		} else {
-			break
-		}
+	fmt.Printf("Servidor escutando em %s (dados em %s)\n", config.ListenAddress, config.DataDir)
+
+	// SIGINT/SIGTERM: encerramento gracioso com snapshot final
+	shutdownDone := make(chan error, 1)
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		sig := <-signals
+		fmt.Printf("\nSinal %v recebido, encerrando servidor...\n", sig)
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		shutdownDone <- server.Shutdown(ctx)
+	}()
+
+	err = server.Serve(l)
+	if err != remotelist.ErrServerClosed {
+		fmt.Println("erro no servidor:", err)
+		os.Exit(1)
+	}
+
+	err = <-shutdownDone
+	if err != nil {
+		fmt.Println("erro no encerramento:", err)
+		os.Exit(1)
 	}
+	fmt.Println("Servidor encerrado")
 }
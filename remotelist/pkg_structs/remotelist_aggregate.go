@@ -0,0 +1,211 @@
+package remotelist
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Operações de Aggregate. count e count_distinct aceitam qualquer tipo de valor;
+// as demais exigem valores numéricos (int ou float)
+var aggregateOps = []string{"count", "count_distinct", "sum", "min", "max", "mean", "median"}
+
+type AggregateArgs struct {
+	ListName string
+	Ops      []string // vazio calcula todas
+	Range    bool     // agrega só lista[Start:End], com os índices de GetRange
+	Start    int
+	End      int
+	ToEnd    bool
+}
+
+// AggregateReply traz o resultado de cada operação pelo nome. Em um intervalo vazio
+// min, max, mean e median não são definidos e ficam de fora
+type AggregateReply struct {
+	Results map[string]Value
+	Version uint64 // versão da lista no momento da leitura
+}
+
+// Aggregate calcula as operações sobre a lista (ou um intervalo dela) no servidor,
+// sob o read lock, em vez de o cliente ler os elementos um a um.
+// sum de inteiros é inteira (erro em overflow) e vira float se houver algum float;
+// min, max e median (com quantidade ímpar) retornam o próprio elemento; mean e
+// median (com quantidade par) retornam float
+func (l *RemoteList) Aggregate(args AggregateArgs, reply *AggregateReply) error {
+	ops := args.Ops
+	if len(ops) == 0 {
+		ops = aggregateOps
+	}
+	for _, op := range ops {
+		if !isAggregateOp(op) {
+			return errors.New("unknown aggregate: " + op)
+		}
+	}
+
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	list, err := l.getList(args.ListName)
+	if err != nil {
+		return err
+	}
+
+	start, end := 0, list.items.Len()
+	if args.Range {
+		if !args.ToEnd {
+			end = args.End
+		}
+		start, end = normalizeRange(args.Start, end, list.items.Len())
+	}
+	values := list.items.Slice(start, end)
+
+	results := make(map[string]Value, len(ops))
+	for _, op := range ops {
+		result, defined, err := aggregate(op, values)
+		if err != nil {
+			return fmt.Errorf("%s: %v", op, err)
+		}
+		if defined {
+			results[op] = result
+		}
+	}
+
+	reply.Results = results
+	reply.Version = list.version
+	return nil
+}
+
+func isAggregateOp(op string) bool {
+	for _, known := range aggregateOps {
+		if op == known {
+			return true
+		}
+	}
+	return false
+}
+
+// aggregate calcula uma operação; defined é falso quando ela não tem valor para
+// uma lista vazia
+func aggregate(op string, values []Value) (result Value, defined bool, err error) {
+	switch op {
+	case "count":
+		return IntValue(len(values)), true, nil
+	case "count_distinct":
+		distinct := make(map[Value]struct{}, len(values))
+		for _, v := range values {
+			distinct[v] = struct{}{}
+		}
+		return IntValue(len(distinct)), true, nil
+	case "sum":
+		result, err = sumValues(values)
+		return result, true, err
+	}
+
+	for _, v := range values {
+		if _, err := v.asFloat(); err != nil {
+			return Value{}, false, err
+		}
+	}
+	if len(values) == 0 {
+		return Value{}, false, nil
+	}
+
+	switch op {
+	case "min", "max":
+		best := values[0]
+		for _, v := range values[1:] {
+			c := compareNumbers(v, best)
+			if (op == "min" && c < 0) || (op == "max" && c > 0) {
+				best = v
+			}
+		}
+		return best, true, nil
+	case "mean":
+		var total float64
+		for _, v := range values {
+			f, _ := v.asFloat()
+			total += f
+		}
+		return FloatValue(total / float64(len(values))), true, nil
+	case "median":
+		sorted := append([]Value(nil), values...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return compareNumbers(sorted[i], sorted[j]) < 0
+		})
+		middle := len(sorted) / 2
+		if len(sorted)%2 == 1 {
+			return sorted[middle], true, nil
+		}
+		a, _ := sorted[middle-1].asFloat()
+		b, _ := sorted[middle].asFloat()
+		return FloatValue(a/2 + b/2), true, nil
+	}
+	return Value{}, false, errors.New("unknown aggregate: " + op)
+}
+
+// sumValues soma os valores: inteiros enquanto todos forem inteiros, float se houver
+// algum float. O overflow da soma inteira só é erro se todos forem inteiros: um
+// float em qualquer posição faz o resultado ser a soma em float
+func sumValues(values []Value) (Value, error) {
+	intSum, allInts, overflow := 0, true, false
+	var floatSum float64
+	for _, v := range values {
+		f, err := v.asFloat()
+		if err != nil {
+			return Value{}, err
+		}
+		floatSum += f
+		if n, ok := v.Int(); ok && allInts {
+			if (n > 0 && intSum > math.MaxInt-n) || (n < 0 && intSum < math.MinInt-n) {
+				overflow = true
+			}
+			intSum += n
+		} else {
+			allInts = false
+		}
+	}
+	if !allInts {
+		return FloatValue(floatSum), nil
+	}
+	if overflow {
+		return Value{}, errors.New("integer overflow")
+	}
+	return IntValue(intSum), nil
+}
+
+// compareNumbers compara dois valores numéricos: inteiros como inteiros (sem perda
+// de precisão), os demais como float64. NaN vem antes de qualquer número, para
+// que a ordem seja total
+func compareNumbers(a, b Value) int {
+	x, aInt := a.Int()
+	y, bInt := b.Int()
+	if aInt && bInt {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+
+	f, _ := a.asFloat()
+	g, _ := b.asFloat()
+	fNaN, gNaN := math.IsNaN(f), math.IsNaN(g)
+	switch {
+	case fNaN || gNaN:
+		if fNaN == gNaN {
+			return 0
+		}
+		if fNaN {
+			return -1
+		}
+		return 1
+	case f < g:
+		return -1
+	case f > g:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,26 @@
+package remotelist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSumValues(t *testing.T) {
+	cases := []struct {
+		name   string
+		values []Value
+		want   Value
+		err    bool
+	}{
+		{"inteiros", []Value{IntValue(1), IntValue(2), IntValue(3)}, IntValue(6), false},
+		{"overflow", []Value{IntValue(math.MaxInt), IntValue(1)}, Value{}, true},
+		{"overflow antes de um float", []Value{IntValue(math.MaxInt), IntValue(1), FloatValue(0.5)}, FloatValue(float64(math.MaxInt) + 1.5), false},
+		{"float antes do overflow", []Value{FloatValue(0.5), IntValue(math.MaxInt), IntValue(1)}, FloatValue(float64(math.MaxInt) + 1.5), false},
+	}
+	for _, c := range cases {
+		got, err := sumValues(c.values)
+		if (err != nil) != c.err || got != c.want {
+			t.Fatalf("%s: recebido %v (err=%v), esperado %v", c.name, got, err, c.want)
+		}
+	}
+}
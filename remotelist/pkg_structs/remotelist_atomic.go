@@ -0,0 +1,109 @@
+package remotelist
+
+import (
+	"errors"
+	"math"
+)
+
+type CompareAndSetArgs struct {
+	ListName string
+	Index    int
+	Expected Value
+	New      Value
+}
+
+type CompareAndSetReply struct {
+	Swapped bool
+	Current Value // valor na posição depois da chamada
+}
+
+type AddAtArgs struct {
+	ListName string
+	Index    int
+	Delta    int
+}
+
+// As operações abaixo são registradas no WAL como SET do valor resultante, então
+// o replay não depende de reler o valor anterior
+
+// compareAndSetLocked troca o valor na posição index por newValue se ele for igual a
+// expected. Um valor diferente não é erro: retorna swapped falso e o valor atual
+func (l *RemoteList) compareAndSetLocked(name string, index int, expected, newValue Value) (bool, Value, uint64, error) {
+	current, err := l.getLocked(name, index)
+	if err != nil {
+		return false, Value{}, 0, err
+	}
+	if current != expected {
+		return false, current, 0, nil
+	}
+
+	_, lsn, err := l.setLocked(name, index, newValue)
+	if err != nil {
+		return false, current, 0, err
+	}
+	return true, newValue, lsn, nil
+}
+
+// addAtLocked soma delta ao inteiro na posição index e retorna o novo valor
+func (l *RemoteList) addAtLocked(name string, index, delta int) (int, uint64, error) {
+	value, err := l.getLocked(name, index)
+	if err != nil {
+		return 0, 0, err
+	}
+	current, err := value.asInt()
+	if err != nil {
+		return 0, 0, err
+	}
+	if (delta > 0 && current > math.MaxInt-delta) || (delta < 0 && current < math.MinInt-delta) {
+		return 0, 0, errors.New("integer overflow")
+	}
+
+	newValue := current + delta
+	_, lsn, err := l.setLocked(name, index, IntValue(newValue))
+	if err != nil {
+		return 0, 0, err
+	}
+	return newValue, lsn, nil
+}
+
+// CompareAndSet troca o valor na posição Index por New só se ele ainda for Expected
+func (l *RemoteList) CompareAndSet(args CompareAndSetArgs, reply *CompareAndSetReply) error {
+	l.lockLists(args.ListName)
+	swapped, current, lsn, err := l.compareAndSetLocked(args.ListName, args.Index, args.Expected, args.New)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if swapped {
+		err = l.waitDurable(lsn)
+		if err != nil {
+			return err
+		}
+	}
+
+	reply.Swapped = swapped
+	reply.Current = current
+	return nil
+}
+
+// AddAt soma Delta ao inteiro na posição Index atomicamente e retorna o novo valor,
+// permitindo usar os elementos como contadores
+func (l *RemoteList) AddAt(args AddAtArgs, reply *int) error {
+	*reply = 0
+
+	l.lockLists(args.ListName)
+	newValue, lsn, err := l.addAtLocked(args.ListName, args.Index, args.Delta)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = newValue
+	return nil
+}
@@ -0,0 +1,147 @@
+package remotelist
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const maxBatchOps = 10000
+
+// BatchOp é uma operação dentro de um Batch. Op é o nome da RPC equivalente:
+// "Append", "PushFront", "Remove", "PopFront", "Insert", "Set", "RemoveAt", "Get",
+// "Size", "Clear", "DeleteList", "RenameList", "AppendIfVersion", "RemoveIfVersion",
+// "SetIfVersion", "AddAt", "SetTTL" ou "SetCap"; os demais campos são os argumentos dela
+type BatchOp struct {
+	Op              string
+	ListName        string
+	NewName         string        // RenameList
+	Index           int           // Get, Insert, Set, RemoveAt, SetIfVersion e AddAt
+	Value           Value         // Append, PushFront, Insert, Set e *IfVersion
+	Delta           int           // AddAt
+	ExpectedVersion uint64        // *IfVersion
+	TTL             time.Duration // Append (expiração do elemento) e SetTTL
+	MaxLength       int           // SetCap
+	Policy          CapPolicy     // SetCap
+}
+
+type BatchArgs struct {
+	Ops []BatchOp
+}
+
+// BatchResult é o resultado de uma operação: o mesmo valor que a RPC equivalente
+// retornaria (inteiro 0 para as que retornam bool) ou a mensagem de erro
+type BatchResult struct {
+	Value   Value
+	Error   string // vazio em caso de sucesso
+	Dropped bool   // Append, PushFront, Insert ou AppendIfVersion em lista cheia com CapDropNewest: o valor foi descartado
+}
+
+type BatchReply struct {
+	Results []BatchResult // na mesma ordem de Ops
+}
+
+// Batch executa as operações em ordem, com uma única aquisição do lock e uma única
+// espera de durabilidade. As operações são independentes: o erro de uma não
+// desfaz nem impede as seguintes (para tudo ou nada, use uma transação)
+func (l *RemoteList) Batch(args BatchArgs, reply *BatchReply) error {
+	if len(args.Ops) > maxBatchOps {
+		return fmt.Errorf("batch too large: %d operations (max %d)", len(args.Ops), maxBatchOps)
+	}
+
+	results := make([]BatchResult, len(args.Ops))
+	var lastLSN uint64
+
+	l.mu.Lock()
+	for i, op := range args.Ops {
+		value, lsn, err := l.execOpLocked(op)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Value = value
+		results[i].Dropped = dropped(op, lsn)
+		if lsn > lastLSN {
+			lastLSN = lsn
+		}
+	}
+	l.mu.Unlock()
+
+	// O group commit é em ordem de LSN: esperar a última escrita cobre todas
+	if lastLSN > 0 {
+		err := l.waitDurable(lastLSN)
+		if err != nil {
+			return err
+		}
+	}
+
+	reply.Results = results
+	return nil
+}
+
+// dropped informa se uma operação que adiciona um elemento terminou sem erro e sem
+// escrita, ou seja, o valor foi descartado por CapDropNewest
+func dropped(op BatchOp, lsn uint64) bool {
+	switch op.Op {
+	case "Append", "PushFront", "Insert", "AppendIfVersion":
+		return lsn == 0
+	}
+	return false
+}
+
+// execOpLocked executa uma operação de Batch. Retorna LSN 0 para as leituras.
+// Deve ser chamado com o write lock
+func (l *RemoteList) execOpLocked(op BatchOp) (Value, uint64, error) {
+	l.expireLocked(op.ListName, op.NewName)
+
+	switch op.Op {
+	case "Append":
+		lsn, err := l.appendLocked(op.ListName, op.Value, op.TTL)
+		return Value{}, lsn, err
+	case "PushFront":
+		lsn, err := l.pushFrontLocked(op.ListName, op.Value)
+		return Value{}, lsn, err
+	case "Remove":
+		return l.removeLocked(op.ListName, false)
+	case "PopFront":
+		return l.removeLocked(op.ListName, true)
+	case "Insert":
+		lsn, err := l.insertLocked(op.ListName, op.Index, op.Value)
+		return Value{}, lsn, err
+	case "Set":
+		return l.setLocked(op.ListName, op.Index, op.Value)
+	case "RemoveAt":
+		return l.removeAtLocked(op.ListName, op.Index)
+	case "Get":
+		value, err := l.getLocked(op.ListName, op.Index)
+		return value, 0, err
+	case "Size":
+		return IntValue(l.sizeLocked(op.ListName)), 0, nil
+	case "Clear":
+		lsn, err := l.clearLocked(op.ListName)
+		return Value{}, lsn, err
+	case "DeleteList":
+		lsn, err := l.deleteListLocked(op.ListName)
+		return Value{}, lsn, err
+	case "RenameList":
+		lsn, err := l.renameListLocked(op.ListName, op.NewName)
+		return Value{}, lsn, err
+	case "AppendIfVersion":
+		lsn, err := l.appendIfVersionLocked(op.ListName, op.Value, op.ExpectedVersion)
+		return Value{}, lsn, err
+	case "RemoveIfVersion":
+		return l.removeIfVersionLocked(op.ListName, op.ExpectedVersion)
+	case "SetIfVersion":
+		return l.setIfVersionLocked(op.ListName, op.Index, op.Value, op.ExpectedVersion)
+	case "AddAt":
+		newValue, lsn, err := l.addAtLocked(op.ListName, op.Index, op.Delta)
+		return IntValue(newValue), lsn, err
+	case "SetTTL":
+		lsn, err := l.setTTLLocked(op.ListName, op.TTL)
+		return Value{}, lsn, err
+	case "SetCap":
+		lsn, err := l.setCapLocked(op.ListName, op.MaxLength, op.Policy)
+		return Value{}, lsn, err
+	}
+	return Value{}, 0, errors.New("unknown operation: " + op.Op)
+}
@@ -0,0 +1,162 @@
+package remotelist
+
+import (
+	"errors"
+	"time"
+)
+
+type BlockingRemoveArgs struct {
+	ListName string
+	Timeout  time.Duration // <= 0 espera sem limite (até o encerramento do servidor)
+}
+
+type BlockingPopFrontArgs struct {
+	ListName string
+	Timeout  time.Duration // <= 0 espera sem limite (até o encerramento do servidor)
+}
+
+// popWaiter é uma chamada bloqueada esperando elementos em uma lista
+type popWaiter struct {
+	ready chan struct{} // buffer 1: a lista pode ter elementos
+}
+
+// BlockingRemove remove e retorna o último elemento, esperando até Timeout a lista
+// ter elementos. A lista não precisa existir: um Append posterior a cria
+func (l *RemoteList) BlockingRemove(args BlockingRemoveArgs, reply *Value) error {
+	value, err := l.blockingPop(args.ListName, args.Timeout, false)
+	*reply = value
+	return err
+}
+
+// BlockingPopFront remove e retorna o primeiro elemento, esperando até Timeout a
+// lista ter elementos
+func (l *RemoteList) BlockingPopFront(args BlockingPopFrontArgs, reply *Value) error {
+	value, err := l.blockingPop(args.ListName, args.Timeout, true)
+	*reply = value
+	return err
+}
+
+// blockingPop atende as chamadas bloqueantes. As chamadas esperando a mesma lista
+// formam uma fila: só a primeira retira elementos, então quem chegou antes é atendido
+// antes. A espera acontece sem o lock; as escritas que adicionam elementos acordam
+// a primeira da fila (signalWaiter) e cada uma que sai passa o sinal adiante
+func (l *RemoteList) blockingPop(name string, timeout time.Duration, front bool) (Value, error) {
+	var timeoutCh <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
+	w := &popWaiter{ready: make(chan struct{}, 1)}
+	queued := false
+
+	l.lockLists(name)
+	for {
+		queue := l.waiters[name]
+		myTurn := (!queued && len(queue) == 0) || (queued && queue[0] == w)
+
+		list, err := l.getList(name)
+		if err == nil && list.items.Len() > 0 && myTurn {
+			if queued {
+				l.removeWaiter(name, w)
+			}
+			value, lsn, err := l.popLocked(list, front)
+			if list.items.Len() > 0 {
+				l.signalWaiter(name)
+			}
+			l.mu.Unlock()
+			if err != nil {
+				return Value{}, err
+			}
+
+			err = l.waitDurable(lsn)
+			if err != nil {
+				return Value{}, err
+			}
+			return value, nil
+		}
+
+		if !queued {
+			l.waiters[name] = append(queue, w)
+			queued = true
+		}
+		l.mu.Unlock()
+
+		var waitErr error
+		select {
+		case <-w.ready:
+		case <-timeoutCh:
+			waitErr = errors.New("timeout waiting for element")
+		case <-l.waitStopCh:
+			waitErr = errors.New("server shutting down")
+		}
+
+		l.lockLists(name)
+		if waitErr != nil {
+			l.removeWaiter(name, w)
+			// Um sinal recebido junto com o timeout não pode se perder: passa ao próximo
+			if list, err := l.getList(name); err == nil && list.items.Len() > 0 {
+				l.signalWaiter(name)
+			}
+			l.mu.Unlock()
+			return Value{}, waitErr
+		}
+	}
+}
+
+// popLocked remove o último (ou o primeiro, se front) elemento, registrando no WAL
+// a mesma operação de Remove ou PopFront. Deve ser chamado com o write lock
+func (l *RemoteList) popLocked(list *listState, front bool) (Value, uint64, error) {
+	operation, index := "REMOVE", list.items.Len()-1
+	if front {
+		operation, index = "POP_FRONT", 0
+	}
+
+	value := list.items.At(index)
+	entry := l.newListEntry(operation, list.name)
+	entry.Value = value
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	l.logf("Lista '%s': %v (removido: %v)\n", list.name, list.items, value)
+	return value, lsn, nil
+}
+
+// signalWaiter acorda a primeira chamada esperando a lista. Deve ser chamado com o
+// write lock; durante o replay não há chamadas esperando
+func (l *RemoteList) signalWaiter(name string) {
+	queue := l.waiters[name]
+	if len(queue) == 0 {
+		return
+	}
+	select {
+	case queue[0].ready <- struct{}{}:
+	default: // já sinalizada
+	}
+}
+
+// removeWaiter tira a chamada da fila de espera da lista
+func (l *RemoteList) removeWaiter(name string, w *popWaiter) {
+	queue := l.waiters[name]
+	for i, other := range queue {
+		if other == w {
+			queue = append(queue[:i], queue[i+1:]...)
+			break
+		}
+	}
+	if len(queue) == 0 {
+		delete(l.waiters, name)
+	} else {
+		l.waiters[name] = queue
+	}
+}
+
+// stopWaiting encerra as chamadas bloqueadas com erro. O Server chama no início do
+// Shutdown para que elas não segurem o encerramento até o prazo
+func (l *RemoteList) stopWaiting() {
+	l.waitStopOnce.Do(func() {
+		close(l.waitStopCh)
+	})
+}
@@ -0,0 +1,133 @@
+package remotelist
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CapPolicy define o que acontece ao adicionar um elemento a uma lista cheia
+type CapPolicy string
+
+const (
+	CapDropOldest CapPolicy = "drop_oldest" // remove o elemento da outra ponta (buffer circular dos últimos N)
+	CapDropNewest CapPolicy = "drop_newest" // descarta o elemento que seria adicionado
+	CapReject     CapPolicy = "reject"      // a escrita falha com ErrListFull
+)
+
+// ErrListFull é retornado ao adicionar a uma lista cheia com a política CapReject
+var ErrListFull = errors.New("list is full")
+
+// ParseCapPolicy valida o nome de uma política de limite
+func ParseCapPolicy(value string) (CapPolicy, error) {
+	switch policy := CapPolicy(strings.ToLower(value)); policy {
+	case CapDropOldest, CapDropNewest, CapReject:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid cap policy: %q (use drop_oldest, drop_newest or reject)", value)
+}
+
+type SetCapArgs struct {
+	ListName  string
+	MaxLength int       // <= 0 remove o limite
+	Policy    CapPolicy // vazio equivale a CapDropOldest
+}
+
+// full informa se a lista tem limite e já está nele
+func (list *listState) full() bool {
+	return list.maxLength > 0 && list.items.Len() >= list.maxLength
+}
+
+// admitLocked verifica se um elemento pode ser adicionado à lista name. Retorna
+// false, sem erro, se a lista está cheia com CapDropNewest: o elemento é descartado
+// sem ser gravado. Com CapDropOldest o elemento é aceito e applyEntry remove o da
+// outra ponta. Deve ser chamado com o lock
+func (l *RemoteList) admitLocked(name string) (bool, error) {
+	list, err := l.getList(name)
+	if err != nil || !list.full() {
+		return true, nil // lista inexistente é criada sem limite
+	}
+
+	switch list.capPolicy {
+	case CapDropNewest:
+		l.logf("Lista '%s' cheia (%d elementos): valor descartado\n", name, list.maxLength)
+		return false, nil
+	case CapReject:
+		return false, ErrListFull
+	}
+	return true, nil
+}
+
+// enforceCap remove elementos até a lista caber no limite, depois de uma inserção
+// (atFront: a inserção foi no início). CapDropOldest remove da ponta oposta à
+// inserção; as demais políticas removem da mesma ponta. Faz parte de applyEntry,
+// então o replay remove exatamente os mesmos elementos
+func (list *listState) enforceCap(atFront bool) {
+	if list.maxLength <= 0 {
+		return
+	}
+	fromFront := !atFront
+	if list.capPolicy != CapDropOldest {
+		fromFront = atFront
+	}
+	for list.items.Len() > list.maxLength {
+		if fromFront {
+			list.items.PopFront()
+		} else {
+			list.items.PopBack()
+		}
+	}
+}
+
+// setCapLocked define o limite da lista, criando-a se não existir
+func (l *RemoteList) setCapLocked(name string, maxLength int, policy CapPolicy) (uint64, error) {
+	if policy == "" {
+		policy = CapDropOldest
+	}
+	policy, err := ParseCapPolicy(string(policy))
+	if err != nil {
+		return 0, err
+	}
+	if maxLength < 0 {
+		maxLength = 0
+	}
+
+	if list, err := l.getList(name); err == nil && policy == CapReject && maxLength > 0 && list.items.Len() > maxLength {
+		return 0, fmt.Errorf("list has %d elements, more than the cap (%d)", list.items.Len(), maxLength)
+	}
+
+	entry := l.newListEntry("SET_CAP", name)
+	entry.MaxLength = maxLength
+	entry.CapPolicy = policy
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	if maxLength == 0 {
+		l.logf("Lista '%s' sem limite\n", name)
+	} else {
+		l.logf("Lista '%s' limitada a %d elementos (%s): %v\n", name, maxLength, policy, l.lists[entry.ListUUID].items)
+	}
+	return lsn, nil
+}
+
+// SetCap limita a lista a MaxLength elementos, criando-a se não existir. Se a lista
+// já passar do limite, as políticas de descarte removem o excesso como em uma
+// inserção no fim (CapDropOldest remove os primeiros, CapDropNewest os últimos) e
+// CapReject retorna erro
+func (l *RemoteList) SetCap(args SetCapArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.setCapLocked(args.ListName, args.MaxLength, args.Policy)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
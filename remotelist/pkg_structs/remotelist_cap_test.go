@@ -0,0 +1,82 @@
+package remotelist
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestInsertIntoFullDropOldestList(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	cases := []struct {
+		index int
+		want  string
+	}{
+		{0, "[99 1 2]"},
+		{1, "[99 2 3]"},
+		{2, "[2 99 3]"},
+		{3, "[2 3 99]"},
+	}
+	for _, c := range cases {
+		name := fmt.Sprintf("insert_%d", c.index)
+		var ok bool
+		list.SetCap(SetCapArgs{ListName: name, MaxLength: 3, Policy: CapDropOldest}, &ok)
+		for i := 1; i <= 3; i++ {
+			list.Append(AppendArgs{ListName: name, Value: IntValue(i)}, &ok)
+		}
+
+		ok = false
+		err := list.Insert(InsertArgs{ListName: name, Index: c.index, Value: IntValue(99)}, &ok)
+		var reply RangeReply
+		list.GetRange(GetRangeArgs{ListName: name, ToEnd: true}, &reply)
+		if err != nil || !ok || fmt.Sprint(reply.Values) != c.want {
+			t.Fatalf("Insert(%d, 99): err=%v ok=%v lista=%v, esperado %s", c.index, err, ok, reply.Values, c.want)
+		}
+	}
+}
+
+func TestBatchReportsDroppedValues(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	var ok bool
+	list.SetCap(SetCapArgs{ListName: "l", MaxLength: 1, Policy: CapDropNewest}, &ok)
+	ops := []BatchOp{
+		{Op: "Append", ListName: "l", Value: IntValue(1)},
+		{Op: "Append", ListName: "l", Value: IntValue(2)},
+		{Op: "PushFront", ListName: "l", Value: IntValue(3)},
+		{Op: "Insert", ListName: "l", Index: 0, Value: IntValue(4)},
+		{Op: "Size", ListName: "l"},
+	}
+	want := []bool{false, true, true, true, false}
+
+	var batch, tx BatchReply
+	err = list.Batch(BatchArgs{Ops: ops}, &batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list.Clear(ClearArgs{ListName: "l"}, &ok)
+	err = list.Transaction(TransactionArgs{Ops: ops}, &tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, reply := range map[string]BatchReply{"Batch": batch, "Transaction": tx} {
+		for i, result := range reply.Results {
+			if result.Dropped != want[i] {
+				t.Fatalf("%s: operação %d (%s) com Dropped = %v", name, i, ops[i].Op, result.Dropped)
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package remotelist
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config reúne os parâmetros do servidor. Os valores são aplicados em camadas:
+// padrão < arquivo de configuração < variáveis de ambiente < flags
+type Config struct {
+	DataDir           string         // diretório do WAL e dos snapshots
+	ListenAddress     string         // endereço TCP do servidor RPC
+	SnapshotInterval  time.Duration  // intervalo entre snapshots automáticos
+	SnapshotRetention int            // quantidade de snapshots mantidos em disco
+	ExpiryInterval    time.Duration  // intervalo entre varreduras de listas e elementos expirados
+	Durability        DurabilityMode // política de fsync do WAL
+	WALBatchDelay     time.Duration  // espera máxima do group commit (modo always)
+	WALSyncInterval   time.Duration  // período de fsync (modo interval)
+}
+
+// Chaves de configuração: nome no arquivo JSON, sufixo da variável de ambiente
+// (REMOTELIST_<CHAVE>) e, com "-" no lugar de "_", nome da flag
+var configKeys = map[string]string{
+	"data_dir":           "diretório de dados (WAL e snapshots)",
+	"listen_address":     "endereço TCP do servidor",
+	"snapshot_interval":  "intervalo entre snapshots (ex: 120s)",
+	"snapshot_retention": "quantidade de snapshots mantidos",
+	"expiry_interval":    "intervalo entre varreduras de TTL (ex: 1s)",
+	"wal_durability":     "durabilidade do WAL: always, interval ou none",
+	"wal_batch_delay":    "espera máxima do group commit (ex: 2ms)",
+	"wal_sync_interval":  "período de fsync no modo interval (ex: 100ms)",
+}
+
+// DefaultConfig retorna a configuração padrão do servidor
+func DefaultConfig() Config {
+	return Config{
+		DataDir:           "data",
+		ListenAddress:     "localhost:5000",
+		SnapshotInterval:  120 * time.Second,
+		SnapshotRetention: 3,
+		ExpiryInterval:    time.Second,
+		Durability:        DurabilityAlways,
+		WALBatchDelay:     defaultWALMaxBatchDelay,
+		WALSyncInterval:   defaultWALSyncInterval,
+	}
+}
+
+// Set altera um parâmetro a partir do seu valor textual
+func (c *Config) Set(key, value string) error {
+	var err error
+	switch key {
+	case "data_dir":
+		c.DataDir = value
+	case "listen_address":
+		c.ListenAddress = value
+	case "snapshot_interval":
+		c.SnapshotInterval, err = time.ParseDuration(value)
+	case "snapshot_retention":
+		c.SnapshotRetention, err = strconv.Atoi(value)
+	case "expiry_interval":
+		c.ExpiryInterval, err = time.ParseDuration(value)
+	case "wal_durability":
+		c.Durability, err = ParseDurabilityMode(value)
+	case "wal_batch_delay":
+		c.WALBatchDelay, err = time.ParseDuration(value)
+	case "wal_sync_interval":
+		c.WALSyncInterval, err = time.ParseDuration(value)
+	default:
+		return fmt.Errorf("parâmetro de configuração desconhecido: %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("valor inválido para %s: %v", key, err)
+	}
+	return nil
+}
+
+// Validate verifica se a configuração pode ser usada para iniciar o servidor
+func (c *Config) Validate() error {
+	switch {
+	case c.DataDir == "":
+		return errors.New("data_dir não pode ser vazio")
+	case c.ListenAddress == "":
+		return errors.New("listen_address não pode ser vazio")
+	case c.SnapshotInterval <= 0:
+		return errors.New("snapshot_interval deve ser positivo")
+	case c.SnapshotRetention < 1:
+		return errors.New("snapshot_retention deve ser pelo menos 1")
+	case c.ExpiryInterval <= 0:
+		return errors.New("expiry_interval deve ser positivo")
+	case c.WALBatchDelay < 0:
+		return errors.New("wal_batch_delay não pode ser negativo")
+	case c.WALSyncInterval <= 0:
+		return errors.New("wal_sync_interval deve ser positivo")
+	}
+	_, err := ParseDurabilityMode(string(c.Durability))
+	return err
+}
+
+// LoadConfigFile aplica sobre c os parâmetros de um arquivo JSON, por exemplo:
+//
+//	{"data_dir": "/var/lib/remotelist", "snapshot_interval": "60s", "snapshot_retention": 5}
+func (c *Config) LoadConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler arquivo de configuração: %v", err)
+	}
+
+	var values map[string]interface{}
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return fmt.Errorf("erro ao decodificar arquivo de configuração %s: %v", path, err)
+	}
+
+	for key, value := range values {
+		var text string
+		switch v := value.(type) {
+		case string:
+			text = v
+		case float64:
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return fmt.Errorf("valor inválido para %s em %s", key, path)
+		}
+
+		err = c.Set(key, text)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyEnv aplica sobre c as variáveis de ambiente REMOTELIST_<CHAVE> definidas
+func (c *Config) ApplyEnv() error {
+	for key := range configKeys {
+		value, ok := os.LookupEnv("REMOTELIST_" + strings.ToUpper(key))
+		if !ok || value == "" {
+			continue
+		}
+		err := c.Set(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadConfig monta a configuração do servidor a partir dos argumentos de linha de
+// comando. O arquivo de configuração vem de -config ou de REMOTELIST_CONFIG
+func LoadConfig(args []string) (Config, error) {
+	config := DefaultConfig()
+
+	fs := flag.NewFlagSet("remotelist", flag.ContinueOnError)
+	configPath := fs.String("config", os.Getenv("REMOTELIST_CONFIG"), "arquivo de configuração JSON")
+
+	// As flags são aplicadas por último, depois do arquivo e do ambiente
+	type flagValue struct{ key, value string }
+	var flagValues []flagValue
+
+	keys := make([]string, 0, len(configKeys))
+	for key := range configKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		key := key
+		fs.Func(strings.ReplaceAll(key, "_", "-"), configKeys[key], func(value string) error {
+			// Valida já no parse para apontar a flag com erro
+			check := DefaultConfig()
+			err := check.Set(key, value)
+			if err != nil {
+				return err
+			}
+			flagValues = append(flagValues, flagValue{key, value})
+			return nil
+		})
+	}
+
+	err := fs.Parse(args)
+	if err != nil {
+		return config, err
+	}
+
+	if *configPath != "" {
+		err = config.LoadConfigFile(*configPath)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	err = config.ApplyEnv()
+	if err != nil {
+		return config, err
+	}
+
+	for _, fv := range flagValues {
+		err = config.Set(fv.key, fv.value)
+		if err != nil {
+			return config, err
+		}
+	}
+
+	return config, config.Validate()
+}
@@ -0,0 +1,405 @@
+package remotelist
+
+import "fmt"
+
+// deque guarda os elementos de uma lista em um buffer circular: inserção e remoção
+// nas duas pontas e acesso por índice em O(1). Operações no meio (Insert, RemoveAt)
+// deslocam os elementos do lado mais curto
+type deque struct {
+	buf  []Value
+	exp  []int64 // expiração de cada elemento (Unix ms, 0 = não expira), nas mesmas posições de buf; nil se nenhum elemento expira
+	head int     // posição do primeiro elemento em buf
+	n    int     // quantidade de elementos
+
+	counts map[Value]int // índice de valores (opcional): quantas vezes cada valor aparece; nil se desativado
+}
+
+const dequeMinCapacity = 8
+
+// newDequeFrom cria um deque com uma cópia dos valores e das expirações
+// (expiries pode ser nil; senão tem o mesmo tamanho de values)
+func newDequeFrom(values []Value, expiries []int64) deque {
+	d := deque{}
+	if len(values) > 0 {
+		d.buf = make([]Value, len(values))
+		copy(d.buf, values)
+		d.n = len(values)
+	}
+	if len(expiries) == len(values) {
+		for i, expiresAt := range expiries {
+			if expiresAt != 0 {
+				d.setExpiry(i, expiresAt)
+			}
+		}
+	}
+	return d
+}
+
+// clone retorna uma cópia independente do deque
+func (d *deque) clone() deque {
+	c := *d
+	c.buf = append([]Value(nil), d.buf...)
+	if d.exp != nil {
+		c.exp = append([]int64(nil), d.exp...)
+	}
+	if d.counts != nil {
+		c.counts = make(map[Value]int, len(d.counts))
+		for value, count := range d.counts {
+			c.counts[value] = count
+		}
+	}
+	return c
+}
+
+// EnableIndex cria o índice de valores, que passa a ser mantido a cada alteração
+func (d *deque) EnableIndex() {
+	if d.counts != nil {
+		return
+	}
+	d.counts = make(map[Value]int)
+	for i := 0; i < d.n; i++ {
+		d.counts[d.At(i)]++
+	}
+}
+
+// DisableIndex descarta o índice de valores
+func (d *deque) DisableIndex() {
+	d.counts = nil
+}
+
+// Indexed informa se o índice de valores está ativo
+func (d *deque) Indexed() bool {
+	return d.counts != nil
+}
+
+// Count retorna quantas vezes o valor aparece: O(1) com o índice, O(n) sem ele
+func (d *deque) Count(value Value) int {
+	if d.counts != nil {
+		return d.counts[value]
+	}
+	count := 0
+	for i := 0; i < d.n; i++ {
+		if d.At(i) == value {
+			count++
+		}
+	}
+	return count
+}
+
+// index e unindex atualizam o índice de valores (se ativo) quando um elemento
+// entra ou sai do deque
+func (d *deque) index(value Value) {
+	if d.counts != nil {
+		d.counts[value]++
+	}
+}
+
+func (d *deque) unindex(value Value) {
+	if d.counts == nil {
+		return
+	}
+	if d.counts[value] <= 1 {
+		delete(d.counts, value)
+	} else {
+		d.counts[value]--
+	}
+}
+
+// Len retorna a quantidade de elementos
+func (d *deque) Len() int {
+	return d.n
+}
+
+// pos converte um índice lógico em posição no buffer
+func (d *deque) pos(i int) int {
+	return (d.head + i) % len(d.buf)
+}
+
+// At retorna o elemento na posição i (0 <= i < Len)
+func (d *deque) At(i int) Value {
+	return d.buf[d.pos(i)]
+}
+
+// Set substitui o elemento na posição i (0 <= i < Len), mantendo a expiração dele
+func (d *deque) Set(i int, value Value) {
+	d.unindex(d.buf[d.pos(i)])
+	d.buf[d.pos(i)] = value
+	d.index(value)
+}
+
+// ExpiryAt retorna a expiração do elemento na posição i (0 = não expira)
+func (d *deque) ExpiryAt(i int) int64 {
+	if d.exp == nil {
+		return 0
+	}
+	return d.exp[d.pos(i)]
+}
+
+// setExpiry define a expiração do elemento na posição i, criando exp se preciso
+func (d *deque) setExpiry(i int, expiresAt int64) {
+	if d.exp == nil {
+		if expiresAt == 0 {
+			return
+		}
+		d.exp = make([]int64, len(d.buf))
+	}
+	d.exp[d.pos(i)] = expiresAt
+}
+
+// move copia o elemento da posição src para dst, com a expiração. É um passo
+// intermediário de deslocamento e não atualiza o índice de valores
+func (d *deque) move(dst, src int) {
+	d.buf[d.pos(dst)] = d.buf[d.pos(src)]
+	if d.exp != nil {
+		d.exp[d.pos(dst)] = d.exp[d.pos(src)]
+	}
+}
+
+// resize copia os elementos para um buffer novo, começando na posição 0
+func (d *deque) resize(capacity int) {
+	buf := make([]Value, capacity)
+	copyRing(buf, d.buf, d.head, d.n)
+	if d.exp != nil {
+		exp := make([]int64, capacity)
+		copyRing(exp, d.exp, d.head, d.n)
+		d.exp = exp
+	}
+	d.buf = buf
+	d.head = 0
+}
+
+// copyRing copia n elementos do buffer circular src, a partir de head, para dst
+func copyRing[T any](dst, src []T, head, n int) {
+	if n == 0 {
+		return
+	}
+	end := head + n
+	if end <= len(src) {
+		copy(dst, src[head:end])
+	} else {
+		k := copy(dst, src[head:])
+		copy(dst[k:], src[:end-len(src)])
+	}
+}
+
+func (d *deque) grow() {
+	if d.n < len(d.buf) {
+		return
+	}
+	capacity := 2 * len(d.buf)
+	if capacity < dequeMinCapacity {
+		capacity = dequeMinCapacity
+	}
+	d.resize(capacity)
+}
+
+// shrink libera memória quando o deque fica com menos de 1/4 da capacidade
+func (d *deque) shrink() {
+	if len(d.buf) > dequeMinCapacity && d.n < len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// PushBack adiciona ao final
+func (d *deque) PushBack(value Value) {
+	d.PushBackExpiring(value, 0)
+}
+
+// PushBackExpiring adiciona ao final um elemento que expira em expiresAt (Unix ms)
+func (d *deque) PushBackExpiring(value Value, expiresAt int64) {
+	d.grow()
+	d.buf[d.pos(d.n)] = value
+	d.n++
+	d.setExpiry(d.n-1, expiresAt)
+	d.index(value)
+}
+
+// PushFront adiciona ao início
+func (d *deque) PushFront(value Value) {
+	d.PushFrontExpiring(value, 0)
+}
+
+// PushFrontExpiring adiciona ao início um elemento que expira em expiresAt (Unix ms)
+func (d *deque) PushFrontExpiring(value Value, expiresAt int64) {
+	d.grow()
+	d.head = (d.head - 1 + len(d.buf)) % len(d.buf)
+	d.buf[d.head] = value
+	d.n++
+	d.setExpiry(0, expiresAt)
+	d.index(value)
+}
+
+// PopBack remove e retorna o último elemento (Len > 0)
+func (d *deque) PopBack() Value {
+	value := d.popBack()
+	d.unindex(value)
+	return value
+}
+
+// PopFront remove e retorna o primeiro elemento (Len > 0)
+func (d *deque) PopFront() Value {
+	value := d.popFront()
+	d.unindex(value)
+	return value
+}
+
+// popBack e popFront removem uma ponta sem atualizar o índice de valores
+func (d *deque) popBack() Value {
+	d.n--
+	value := d.buf[d.pos(d.n)]
+	d.buf[d.pos(d.n)] = Value{} // libera strings e bytes do elemento removido
+	if d.exp != nil {
+		d.exp[d.pos(d.n)] = 0
+	}
+	d.shrink()
+	return value
+}
+
+func (d *deque) popFront() Value {
+	value := d.buf[d.head]
+	d.buf[d.head] = Value{}
+	if d.exp != nil {
+		d.exp[d.head] = 0
+	}
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	d.shrink()
+	return value
+}
+
+// Insert insere na posição i (0 <= i <= Len), deslocando os elementos seguintes.
+// O elemento inserido não expira
+func (d *deque) Insert(i int, value Value) {
+	if i < d.n/2 {
+		d.PushFront(value)
+		for j := 0; j < i; j++ {
+			d.move(j, j+1)
+		}
+	} else {
+		d.PushBack(value)
+		for j := d.n - 1; j > i; j-- {
+			d.move(j, j-1)
+		}
+	}
+	d.buf[d.pos(i)] = value
+	if d.exp != nil {
+		d.exp[d.pos(i)] = 0
+	}
+}
+
+// RemoveAt remove e retorna o elemento na posição i (0 <= i < Len)
+func (d *deque) RemoveAt(i int) Value {
+	value := d.At(i)
+	if i < d.n/2 {
+		for j := i; j > 0; j-- {
+			d.move(j, j-1)
+		}
+		d.popFront()
+	} else {
+		for j := i; j < d.n-1; j++ {
+			d.move(j, j+1)
+		}
+		d.popBack()
+	}
+	d.unindex(value)
+	return value
+}
+
+// Keep reorganiza o deque com os elementos das posições positions, nessa ordem;
+// os demais são removidos. Cada elemento mantém a expiração
+func (d *deque) Keep(positions []int) {
+	values := make([]Value, len(positions))
+	var expiries []int64
+	if d.exp != nil {
+		expiries = make([]int64, len(positions))
+	}
+	for i, p := range positions {
+		values[i] = d.At(p)
+		if expiries != nil {
+			expiries[i] = d.ExpiryAt(p)
+		}
+	}
+
+	indexed := d.Indexed()
+	*d = newDequeFrom(values, expiries)
+	if indexed {
+		d.EnableIndex()
+	}
+}
+
+// RemoveExpired remove os elementos com expiração até now (Unix ms), mantendo a
+// ordem dos demais, e retorna quantos foram removidos
+func (d *deque) RemoveExpired(now int64) int {
+	if d.exp == nil {
+		return 0
+	}
+	kept := 0
+	for i := 0; i < d.n; i++ {
+		expiresAt := d.ExpiryAt(i)
+		if expiresAt != 0 && expiresAt <= now {
+			d.unindex(d.At(i))
+			continue
+		}
+		if kept != i {
+			d.move(kept, i)
+		}
+		kept++
+	}
+	removed := d.n - kept
+	for d.n > kept {
+		d.popBack()
+	}
+	return removed
+}
+
+// NextExpiry retorna a menor expiração entre os elementos (0 se nenhum expira)
+func (d *deque) NextExpiry() int64 {
+	var next int64
+	for i := 0; d.exp != nil && i < d.n; i++ {
+		expiresAt := d.ExpiryAt(i)
+		if expiresAt != 0 && (next == 0 || expiresAt < next) {
+			next = expiresAt
+		}
+	}
+	return next
+}
+
+// Expiries retorna as expirações em ordem, ou nil se nenhum elemento expira
+func (d *deque) Expiries() []int64 {
+	if d.NextExpiry() == 0 {
+		return nil
+	}
+	expiries := make([]int64, d.n)
+	for i := range expiries {
+		expiries[i] = d.ExpiryAt(i)
+	}
+	return expiries
+}
+
+// Clear remove todos os elementos, mantendo o índice de valores se estiver ativo
+func (d *deque) Clear() {
+	indexed := d.Indexed()
+	*d = deque{}
+	if indexed {
+		d.EnableIndex()
+	}
+}
+
+// Values retorna uma cópia dos elementos em ordem
+func (d *deque) Values() []Value {
+	return d.Slice(0, d.n)
+}
+
+// Slice retorna uma cópia dos elementos em [start, end) (0 <= start <= end <= Len)
+func (d *deque) Slice(start, end int) []Value {
+	values := make([]Value, end-start)
+	for i := range values {
+		values[i] = d.At(start + i)
+	}
+	return values
+}
+
+// String formata os elementos como um slice, para os logs das operações
+func (d deque) String() string {
+	return fmt.Sprint(d.Values())
+}
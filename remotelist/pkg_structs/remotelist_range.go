@@ -0,0 +1,149 @@
+package remotelist
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 1000
+	maxPageSize     = 10000
+)
+
+type GetRangeArgs struct {
+	ListName string
+	Start    int  // índices negativos contam a partir do fim, como em Python
+	End      int  // exclusivo
+	ToEnd    bool // ignora End e vai até o fim da lista (equivale a lista[Start:])
+}
+
+type GetAllArgs struct {
+	ListName string
+	Cursor   string // vazio na primeira página; depois, o NextCursor da página anterior
+	Limit    int    // elementos por página; <= 0 usa o padrão (1000), máximo 10000
+}
+
+// RangeReply traz os elementos lidos junto com o tamanho e a versão da lista no
+// momento da leitura
+type RangeReply struct {
+	Values     []Value
+	Length     int
+	Version    uint64
+	NextCursor string // GetAll: vazio na última página
+}
+
+// normalizeRange converte índices no estilo Python (negativos a partir do fim,
+// fora dos limites ajustados) em um intervalo [start, end) válido
+func normalizeRange(start, end, length int) (int, int) {
+	clamp := func(i int) int {
+		if i < 0 {
+			i += length
+		}
+		if i < 0 {
+			return 0
+		}
+		if i > length {
+			return length
+		}
+		return i
+	}
+
+	start, end = clamp(start), clamp(end)
+	if end < start {
+		end = start
+	}
+	return start, end
+}
+
+// GetRange retorna os elementos de lista[Start:End] em uma única chamada
+func (l *RemoteList) GetRange(args GetRangeArgs, reply *RangeReply) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	list, err := l.getList(args.ListName)
+	if err != nil {
+		return err
+	}
+
+	length := list.items.Len()
+	end := args.End
+	if args.ToEnd {
+		end = length
+	}
+	start, end := normalizeRange(args.Start, end, length)
+
+	reply.Values = list.items.Slice(start, end)
+	reply.Length = length
+	reply.Version = list.version
+	return nil
+}
+
+// GetAll lê a lista inteira em páginas. O cursor guarda a versão da lista: se ela
+// for alterada entre as páginas, a leitura retorna erro e deve recomeçar, em vez de
+// devolver elementos repetidos ou pular elementos
+func (l *RemoteList) GetAll(args GetAllArgs, reply *RangeReply) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	list, err := l.getList(args.ListName)
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	if args.Cursor != "" {
+		version, cursorOffset, err := parseCursor(args.Cursor)
+		if err != nil {
+			return err
+		}
+		if version != list.version {
+			return errors.New("cursor expired: list was modified")
+		}
+		offset = cursorOffset
+	}
+
+	limit := args.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	if limit > maxPageSize {
+		limit = maxPageSize
+	}
+
+	length := list.items.Len()
+	if offset > length {
+		offset = length
+	}
+	end := offset + limit
+	if end > length {
+		end = length
+	}
+
+	reply.Values = list.items.Slice(offset, end)
+	reply.Length = length
+	reply.Version = list.version
+	reply.NextCursor = ""
+	if end < length {
+		reply.NextCursor = fmt.Sprintf("%d:%d", list.version, end)
+	}
+	return nil
+}
+
+// parseCursor decodifica o cursor "<versão>:<posição>" gerado por GetAll
+func parseCursor(cursor string) (uint64, int, error) {
+	versionText, offsetText, found := strings.Cut(cursor, ":")
+	if !found {
+		return 0, 0, errors.New("invalid cursor")
+	}
+	version, err := strconv.ParseUint(versionText, 10, 64)
+	if err != nil {
+		return 0, 0, errors.New("invalid cursor")
+	}
+	offset, err := strconv.Atoi(offsetText)
+	if err != nil || offset < 0 {
+		return 0, 0, errors.New("invalid cursor")
+	}
+	return version, offset, nil
+}
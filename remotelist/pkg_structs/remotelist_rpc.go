@@ -16,7 +16,8 @@ import (
 
 type AppendArgs struct {
 	ListName string
-	Value    int
+	Value    Value
+	TTL      time.Duration // > 0: o elemento expira depois desse tempo
 }
 
 type GetArgs struct {
@@ -28,6 +29,32 @@ type RemoveArgs struct {
 	ListName string
 }
 
+type PushFrontArgs struct {
+	ListName string
+	Value    Value
+}
+
+type PopFrontArgs struct {
+	ListName string
+}
+
+type InsertArgs struct {
+	ListName string
+	Index    int
+	Value    Value
+}
+
+type SetArgs struct {
+	ListName string
+	Index    int
+	Value    Value
+}
+
+type RemoveAtArgs struct {
+	ListName string
+	Index    int
+}
+
 type SizeArgs struct {
 	ListName string
 }
@@ -36,91 +63,176 @@ type ListAllReply struct {
 	ListNames []string
 }
 
+type DeleteListArgs struct {
+	ListName string
+}
+
+type RenameListArgs struct {
+	ListName string
+	NewName  string
+}
+
+type ClearArgs struct {
+	ListName string
+}
+
+type InfoArgs struct {
+	ListName string
+}
+
+type LookupUUIDArgs struct {
+	UUID uuid.UUID
+}
+
+// ListInfo descreve uma lista; o UUID é estável entre reinícios do servidor
+type ListInfo struct {
+	UUID            uuid.UUID
+	Name            string
+	CreatedAtUnix   int64 // Unix (segundos) em que a lista foi criada
+	Size            int
+	Version         uint64
+	ExpiresAtMillis int64 // Unix ms em que a lista expira; 0 = sem TTL
+	MaxLength       int   // limite de elementos; 0 = sem limite
+	CapPolicy       CapPolicy
+	Indexed         bool // índice de valores ativo (ver SetValueIndex)
+}
+
 // Persistência
 type LogEntry struct {
-	LSN       uint64 `json:"lsn"` //Log Sequence Number - "contador global"
-	Timestamp int64  `json:"timestamp"`
-	Operation string `json:"operation"` // "APPEND" ou "REMOVE"
-	ListName  string `json:"list_name"`
-	Value     int    `json:"value"` // 0 para REMOVE
+	LSN        uint64     `json:"lsn"` //Log Sequence Number - "contador global"
+	Timestamp  int64      `json:"timestamp"`
+	Operation  string     `json:"operation"`            // "APPEND", "REMOVE", "PUSH_FRONT", "POP_FRONT", "INSERT", "SET", "REMOVE_AT", "DELETE_LIST", "RENAME_LIST", "CLEAR", "SET_TTL", "EXPIRE", "EXPIRE_LIST", "SET_CAP", "SET_INDEX", "SORT", "REVERSE", "DEDUPE", "TRIM", "SORTED_SET_ADD", "SORTED_SET_REMOVE", "DELETE_SORTED_SET" ou "TX"
+	ListName   string     `json:"list_name"`            // nome da lista ou do conjunto ordenado
+	NewName    string     `json:"new_name,omitempty"`   // RENAME_LIST
+	Index      int        `json:"index,omitempty"`      // INSERT, SET e REMOVE_AT; TRIM: início do intervalo mantido
+	End        int        `json:"end,omitempty"`        // TRIM: fim (exclusivo) do intervalo mantido
+	Descending bool       `json:"descending,omitempty"` // SORT
+	ListUUID   uuid.UUID  `json:"list_uuid"`            // Nil em entradas de versões antigas
+	CreatedAt  int64      `json:"created_at,omitempty"` // preenchido quando a operação cria a lista
+	Value      Value      `json:"value"`                // removido em REMOVE; membro nas operações de conjunto ordenado; ver remotelist_value.go
+	Score      float64    `json:"score,omitempty"`      // SORTED_SET_ADD
+	ExpiresAt  int64      `json:"expires_at,omitempty"` // Unix ms: expiração do elemento (APPEND, PUSH_FRONT) ou da lista (SET_TTL); EXPIRE: instante da varredura
+	MaxLength  int        `json:"max_length,omitempty"` // SET_CAP
+	CapPolicy  CapPolicy  `json:"cap_policy,omitempty"` // SET_CAP
+	Indexed    bool       `json:"indexed,omitempty"`    // SET_INDEX
+	Ops        []LogEntry `json:"ops,omitempty"`        // TX: operações da transação, aplicadas juntas
+}
+
+// ListSnapshot é o estado de uma lista dentro de um snapshot
+type ListSnapshot struct {
+	UUID      uuid.UUID `json:"uuid"`
+	Name      string    `json:"name"`
+	CreatedAt int64     `json:"created_at"`
+	Version   uint64    `json:"version"` // LSN da última alteração
+	ExpiresAt int64     `json:"expires_at,omitempty"`
+	Values    []Value   `json:"values"`
+	Expiries  []int64   `json:"expiries,omitempty"` // expiração de cada elemento, se algum expira
+	MaxLength int       `json:"max_length,omitempty"`
+	CapPolicy CapPolicy `json:"cap_policy,omitempty"`
+	Indexed   bool      `json:"indexed,omitempty"` // índice de valores ativo; é reconstruído na carga
 }
 
 type SnapshotData struct {
-	LSN       uint64           `json:"lsn"`
-	Timestamp int64            `json:"timestamp"`
-	Lists     map[string][]int `json:"lists"` // Nome: dados
+	LSN        uint64              `json:"lsn"`
+	Timestamp  int64               `json:"timestamp"`
+	Lists      map[string][]Value  `json:"lists,omitempty"` // Nome: dados (formato antigo, sem UUID)
+	ListData   []ListSnapshot      `json:"list_data"`
+	SortedSets []SortedSetSnapshot `json:"sorted_sets,omitempty"`
+}
+
+// listState é uma lista em memória, indexada pelo UUID em RemoteList.lists
+type listState struct {
+	uuid       uuid.UUID
+	name       string
+	createdAt  int64
+	version    uint64 // LSN da última operação que alterou a lista
+	items      deque
+	expiresAt  int64 // TTL da lista (Unix ms); 0 = não expira
+	nextExpiry int64 // menor expiração entre os elementos; pode estar adiantada, nunca atrasada
+	maxLength  int   // limite de elementos; 0 = sem limite
+	capPolicy  CapPolicy
 }
 
 type RemoteList struct {
 	mu         sync.RWMutex
 	nameToUUID map[string]uuid.UUID
-	lists      map[uuid.UUID][]int
+	lists      map[uuid.UUID]*listState
+	sortedSets map[string]*sortedSetState // conjuntos ordenados, por nome (ver remotelist_sortedset_rpc.go)
 	currentLSN uint64
-	walFile    *os.File
-}
+	wal        *walWriter
+	config     Config
 
-// writeWAL escreve uma entrada no Write-Ahead Log
-func (l *RemoteList) writeWAL(operation, listName string, value int) error {
-	l.currentLSN++
+	stopCh    chan struct{}  // fechado por Close para parar as rotinas em background
+	bgWG      sync.WaitGroup // rotinas em background (snapshot automático)
+	closeOnce sync.Once
+	closeErr  error
 
-	entry := LogEntry{
-		LSN:       l.currentLSN,
-		Timestamp: time.Now().Unix(),
-		Operation: operation,
-		ListName:  listName,
-		Value:     value,
-	}
-
-	// Serializa para JSON
-	data, err := json.Marshal(entry)
-	if err != nil {
-		return err
-	}
-
-	_, err = l.walFile.WriteString(string(data) + "\n")
-	if err != nil {
-		return err
-	}
-
-	// fsync para garantir que está no disco
-	err = l.walFile.Sync()
-	if err != nil {
-		return err
-	}
+	staging   bool       // cópia usada para preparar uma transação (ver stageTransaction)
+	txEntries []LogEntry // entradas geradas durante o preparo
 
-	return nil
+	waiters      map[string][]*popWaiter // chamadas bloqueantes por nome de lista, em ordem de chegada
+	waitStopCh   chan struct{}           // fechado para encerrar as chamadas bloqueantes
+	waitStopOnce sync.Once
 }
 
 func (l *RemoteList) createSnapshot() error {
-	l.mu.RLock()
+	// Write lock: a cópia do estado e a rotação do WAL acontecem juntas, assim
+	// o segmento novo começa exatamente em snapshotLSN+1
+	l.mu.Lock()
 
 	snapshotLSN := l.currentLSN
 
-	uuidToName := make(map[uuid.UUID]string)
-	for name, uid := range l.nameToUUID {
-		uuidToName[uid] = name
+	listsData := make([]ListSnapshot, 0, len(l.lists))
+	for _, list := range l.lists {
+		listCopy := list.items.Values()
+		listsData = append(listsData, ListSnapshot{
+			UUID:      list.uuid,
+			Name:      list.name,
+			CreatedAt: list.createdAt,
+			Version:   list.version,
+			ExpiresAt: list.expiresAt,
+			Values:    listCopy,
+			Expiries:  list.items.Expiries(),
+			MaxLength: list.maxLength,
+			CapPolicy: list.capPolicy,
+			Indexed:   list.items.Indexed(),
+		})
 	}
 
-	listsData := make(map[string][]int)
-	for uid, data := range l.lists {
-		name := uuidToName[uid]
-		listCopy := make([]int, len(data))
-		copy(listCopy, data)
-		listsData[name] = listCopy
+	setsData := make([]SortedSetSnapshot, 0, len(l.sortedSets))
+	for _, set := range l.sortedSets {
+		setsData = append(setsData, SortedSetSnapshot{
+			Name:      set.name,
+			CreatedAt: set.createdAt,
+			Version:   set.version,
+			Members:   set.members.Members(),
+		})
 	}
 
-	l.mu.RUnlock()
+	// Escritas a partir daqui vão para um segmento novo (LSN > snapshotLSN)
+	err := l.wal.rotate(snapshotLSN + 1)
+	l.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("erro ao rotacionar WAL: %v", err)
+	}
 
 	snapshot := SnapshotData{
-		LSN:       snapshotLSN,
-		Timestamp: time.Now().Unix(),
-		Lists:     listsData,
+		LSN:        snapshotLSN,
+		Timestamp:  time.Now().Unix(),
+		ListData:   listsData,
+		SortedSets: setsData,
 	}
 
-	os.MkdirAll("data", 0755)
+	os.MkdirAll(l.config.DataDir, 0755)
 
 	timestamp := time.Now().Unix()
-	snapshotName := fmt.Sprintf("data/snapshot_%d.json", timestamp)
+	snapshotName := filepath.Join(l.config.DataDir, fmt.Sprintf("snapshot_%d.json", timestamp))
+	sort.Slice(snapshot.ListData, func(i, j int) bool {
+		return snapshot.ListData[i].Name < snapshot.ListData[j].Name
+	})
+	sort.Slice(snapshot.SortedSets, func(i, j int) bool {
+		return snapshot.SortedSets[i].Name < snapshot.SortedSets[j].Name
+	})
 	tmpFile := snapshotName + ".tmp"
 
 	file, err := os.Create(tmpFile)
@@ -149,61 +261,57 @@ func (l *RemoteList) createSnapshot() error {
 
 	fmt.Printf("Snapshot criado: %s LSN=%d, %d listas\n", snapshotName, snapshotLSN, len(listsData))
 
-	err = l.cleanOldSnapshots(3)
+	err = l.cleanOldSnapshots(l.config.SnapshotRetention)
 	if err != nil {
 		fmt.Printf("Aviso: Erro ao limpar snapshots antigos: %v\n", err)
 	}
 
-	err = l.truncateWAL()
+	// Mantém o WAL necessário para recuperar a partir do snapshot retido mais antigo
+	coveredLSN, err := l.oldestRetainedSnapshotLSN()
 	if err != nil {
-		fmt.Printf("Aviso: Erro ao truncar WAL: %v\n", err)
-	}
-
-	return nil
-}
-
-func (l *RemoteList) truncateWAL() error {
-	l.mu.Lock()
-	defer l.mu.Unlock()
-
-	if l.walFile != nil {
-		l.walFile.Close()
+		fmt.Printf("Aviso: Erro ao ler snapshots retidos: %v\n", err)
+		return nil
 	}
-
-	walFile, err := os.OpenFile("data/wal.log", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	err = l.removeOldWALSegments(coveredLSN)
 	if err != nil {
-		return fmt.Errorf("erro ao truncar WAL: %v", err)
+		fmt.Printf("Aviso: Erro ao remover segmentos antigos do WAL: %v\n", err)
 	}
 
-	l.walFile = walFile
-	fmt.Printf("WAL truncado (operacoes ate LSN=%d ja estao no snapshot)\n", l.currentLSN)
 	return nil
 }
 
-func (l *RemoteList) cleanOldSnapshots(keepCount int) error {
-	files, err := os.ReadDir("data")
+// listSnapshots retorna os caminhos dos snapshots, do mais antigo ao mais recente
+func listSnapshots(dir string) ([]string, error) {
+	files, err := os.ReadDir(dir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var snapshots []string
 	for _, file := range files {
 		name := file.Name()
 		if strings.HasPrefix(name, "snapshot_") && strings.HasSuffix(name, ".json") {
-			snapshots = append(snapshots, name)
+			snapshots = append(snapshots, filepath.Join(dir, name))
 		}
 	}
 
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
+
+func (l *RemoteList) cleanOldSnapshots(keepCount int) error {
+	snapshots, err := listSnapshots(l.config.DataDir)
+	if err != nil {
+		return err
+	}
+
 	if len(snapshots) <= keepCount {
 		return nil
 	}
 
-	sort.Strings(snapshots)
-
 	toRemove := snapshots[:len(snapshots)-keepCount]
 	for _, oldSnapshot := range toRemove {
-		fullPath := filepath.Join("data", oldSnapshot)
-		err := os.Remove(fullPath)
+		err := os.Remove(oldSnapshot)
 		if err != nil {
 			fmt.Printf("Aviso: Erro ao remover snapshot antigo %s: %v\n", oldSnapshot, err)
 		} else {
@@ -215,42 +323,85 @@ func (l *RemoteList) cleanOldSnapshots(keepCount int) error {
 }
 
 func (l *RemoteList) findLatestSnapshot() (string, error) {
-	files, err := os.ReadDir("data")
+	snapshots, err := listSnapshots(l.config.DataDir)
 	if err != nil {
 		return "", err
 	}
 
-	var snapshots []string
-	for _, file := range files {
-		name := file.Name()
-		if strings.HasPrefix(name, "snapshot_") && strings.HasSuffix(name, ".json") {
-			snapshots = append(snapshots, name)
-		}
-	}
-
 	if len(snapshots) == 0 {
 		return "", fmt.Errorf("nenhum snapshot encontrado")
 	}
 
-	sort.Strings(snapshots)
-	latestSnapshot := snapshots[len(snapshots)-1]
+	return snapshots[len(snapshots)-1], nil
+}
+
+// loadSnapshot lê e decodifica um arquivo de snapshot
+func loadSnapshot(path string) (SnapshotData, error) {
+	var snapshot SnapshotData
+
+	file, err := os.Open(path)
+	if err != nil {
+		return snapshot, fmt.Errorf("erro ao abrir snapshot: %v", err)
+	}
+	defer file.Close()
+
+	err = json.NewDecoder(file).Decode(&snapshot)
+	if err != nil {
+		return snapshot, fmt.Errorf("erro ao decodificar snapshot: %v", err)
+	}
+
+	return snapshot, nil
+}
+
+// oldestRetainedSnapshotLSN retorna o menor LSN entre os snapshots legíveis em disco.
+// Segmentos do WAL até esse LSN não são necessários por nenhum snapshot retido
+func (l *RemoteList) oldestRetainedSnapshotLSN() (uint64, error) {
+	snapshots, err := listSnapshots(l.config.DataDir)
+	if err != nil {
+		return 0, err
+	}
+
+	// A ordem dos nomes (timestamp) não garante a ordem dos LSNs: todos são lidos
+	var oldestLSN uint64
+	found := false
+	for _, path := range snapshots {
+		snapshot, err := loadSnapshot(path)
+		if err != nil {
+			continue // snapshot corrompido não conta como cobertura
+		}
+		if !found || snapshot.LSN < oldestLSN {
+			oldestLSN = snapshot.LSN
+			found = true
+		}
+	}
 
-	return filepath.Join("data", latestSnapshot), nil
+	if !found {
+		return 0, fmt.Errorf("nenhum snapshot legível encontrado")
+	}
+	return oldestLSN, nil
 }
 
-func (l *RemoteList) startSnapshotRoutine(intervalSeconds int) {
+func (l *RemoteList) startSnapshotRoutine(interval time.Duration) {
+	l.bgWG.Add(1)
 	go func() {
-		ticker := time.NewTicker(time.Duration(intervalSeconds) * time.Second)
+		defer l.bgWG.Done()
+		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
-		for range ticker.C {
+		for {
+			select {
+			case <-l.stopCh:
+				return
+			case <-ticker.C:
+			}
+
 			err := l.createSnapshot()
 			if err != nil {
 				fmt.Printf("Erro ao criar snapshot: %v\n", err)
 			}
 		}
 	}()
-	fmt.Printf("Snapshot automático iniciado (intervalo: %ds)\n", intervalSeconds)
+	fmt.Printf("Snapshot automático iniciado (intervalo: %v)\n", interval)
 }
 
 func (l *RemoteList) Recover() error {
@@ -258,79 +409,83 @@ func (l *RemoteList) Recover() error {
 
 	var snapshotLSN uint64 = 0
 
-	snapshotFile, err := l.findLatestSnapshot()
-	if err != nil {
-		fmt.Println(" Nenhum snapshot encontrado, iniciando do zero")
-	} else {
-		fmt.Printf("Snapshot encontrado: %s\n", snapshotFile)
+	// Tenta do snapshot mais recente para o mais antigo: o WAL é mantido desde
+	// o snapshot retido mais antigo, então qualquer um deles serve de base
+	snapshots, err := listSnapshots(l.config.DataDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao listar snapshots: %v", err)
+	}
 
-		file, err := os.Open(snapshotFile)
-		if err != nil {
-			return fmt.Errorf("erro ao abrir snapshot: %v", err)
-		}
-		defer file.Close()
+	loaded := false
+	for i := len(snapshots) - 1; i >= 0 && !loaded; i-- {
+		snapshotFile := snapshots[i]
+		fmt.Printf("Snapshot encontrado: %s\n", snapshotFile)
 
-		var snapshot SnapshotData
-		decoder := json.NewDecoder(file)
-		err = decoder.Decode(&snapshot)
+		snapshot, err := loadSnapshot(snapshotFile)
 		if err != nil {
-			return fmt.Errorf("erro ao decodificar snapshot: %v", err)
+			fmt.Printf(" Aviso: %v, tentando snapshot anterior\n", err)
+			continue
 		}
 
 		snapshotLSN = snapshot.LSN
 		l.currentLSN = snapshotLSN
 
+		for _, data := range snapshot.ListData {
+			list := l.createList(data.UUID, data.Name, data.CreatedAt)
+			list.items = newDequeFrom(data.Values, data.Expiries)
+			list.version = data.Version
+			list.expiresAt = data.ExpiresAt
+			list.nextExpiry = list.items.NextExpiry()
+			list.maxLength = data.MaxLength
+			list.capPolicy = data.CapPolicy
+			if data.Indexed {
+				list.items.EnableIndex()
+			}
+			if list.version == 0 {
+				// Snapshot anterior às versões: a última alteração é no máximo o LSN do snapshot
+				list.version = snapshotLSN
+			}
+		}
+		// Snapshots antigos não têm UUID: as listas recebem um novo
 		for listName, data := range snapshot.Lists {
-			listUUID := uuid.New()
-			l.nameToUUID[listName] = listUUID
-			l.lists[listUUID] = data
+			list := l.createList(uuid.New(), listName, snapshot.Timestamp)
+			list.items = newDequeFrom(data, nil)
+			list.version = snapshotLSN
+		}
+
+		for _, data := range snapshot.SortedSets {
+			set := l.createSortedSet(data.Name, data.CreatedAt)
+			for _, member := range data.Members {
+				set.members.Add(member.Member, member.Score)
+			}
+			set.version = data.Version
 		}
 
 		fmt.Printf(" LSN do snapshot: %d\n", snapshotLSN)
-		fmt.Printf(" Listas restauradas: %d\n", len(snapshot.Lists))
+		fmt.Printf(" Listas restauradas: %d\n", len(l.lists))
+		fmt.Printf(" Conjuntos ordenados restaurados: %d\n", len(l.sortedSets))
+		loaded = true
+	}
+	if !loaded {
+		fmt.Println(" Nenhum snapshot encontrado, iniciando do zero")
 	}
 
-	//Replay do WAL
-	walFile := "data/wal.log"
-	if _, err := os.Stat(walFile); err == nil {
-		fmt.Println(" WAL encontrado, aplicando operações...")
+	//Replay do WAL: arquivo legado (se houver) seguido dos segmentos em ordem de LSN
+	walFiles, err := walFilesForReplay(l.config.DataDir)
+	if err != nil {
+		return fmt.Errorf("erro ao listar segmentos do WAL: %v", err)
+	}
 
-		file, err := os.Open(walFile)
-		if err != nil {
-			return fmt.Errorf("erro ao abrir WAL: %v", err)
-		}
-		defer file.Close()
+	if len(walFiles) > 0 {
+		fmt.Printf(" WAL encontrado (%d arquivos), aplicando operações...\n", len(walFiles))
 
-		decoder := json.NewDecoder(file)
 		appliedOps := 0
-
-		for {
-			var entry LogEntry
-			err := decoder.Decode(&entry)
+		for i, walFile := range walFiles {
+			applied, err := l.replayWALFile(walFile, snapshotLSN, i == len(walFiles)-1)
 			if err != nil {
-				break
+				return err
 			}
-
-			// Aplica apenas operações APÓS o snapshot
-			if entry.LSN <= snapshotLSN {
-				continue
-			}
-
-			// Replay da operação
-			switch entry.Operation {
-			case "APPEND":
-				listUUID := l.getOrCreateListUUID(entry.ListName)
-				l.lists[listUUID] = append(l.lists[listUUID], entry.Value)
-			case "REMOVE":
-				if listUUID, exists := l.nameToUUID[entry.ListName]; exists {
-					if len(l.lists[listUUID]) > 0 {
-						l.lists[listUUID] = l.lists[listUUID][:len(l.lists[listUUID])-1]
-					}
-				}
-			}
-
-			l.currentLSN = entry.LSN
-			appliedOps++
+			appliedOps += applied
 		}
 
 		fmt.Printf("  Operações aplicadas do WAL: %d\n", appliedOps)
@@ -341,105 +496,513 @@ func (l *RemoteList) Recover() error {
 
 	fmt.Printf("\n Recuperação completa! Estado restaurado.\n")
 	fmt.Printf("  Total de listas: %d\n", len(l.nameToUUID))
-	fmt.Println("================================\n")
+	fmt.Println("================================")
+	fmt.Println()
 
 	return nil
 }
 
-// Retorna o UUID de uma lista, criando-a se não existir
-func (l *RemoteList) getOrCreateListUUID(name string) uuid.UUID {
+// createList registra uma lista vazia com o UUID informado
+func (l *RemoteList) createList(listUUID uuid.UUID, name string, createdAt int64) *listState {
+	list := &listState{
+		uuid:      listUUID,
+		name:      name,
+		createdAt: createdAt,
+	}
+	l.nameToUUID[name] = listUUID
+	l.lists[listUUID] = list
+	return list
+}
+
+// newListEntry monta uma entrada do WAL para a lista name, com o UUID dela.
+// Se a lista não existir, a entrada recebe um UUID novo e a data de criação,
+// e a lista é criada quando a entrada for aplicada
+func (l *RemoteList) newListEntry(operation, name string) LogEntry {
+	entry := LogEntry{Operation: operation, ListName: name}
 	if listUUID, exists := l.nameToUUID[name]; exists {
-		return listUUID
+		entry.ListUUID = listUUID
+	} else {
+		entry.ListUUID = uuid.New()
+		entry.CreatedAt = time.Now().Unix()
 	}
-	newUUID := uuid.New()
-	l.nameToUUID[name] = newUUID
-	l.lists[newUUID] = make([]int, 0)
-	fmt.Printf("Nova lista criada: '%s' UUID: %s\n", name, newUUID)
-	return newUUID
+	return entry
 }
 
-func (l *RemoteList) Append(args AppendArgs, reply *bool) error {
-	l.mu.Lock() // Write lock - acesso exclusivo (bloqueia leitores e escritores)
-	defer l.mu.Unlock()
+// entryList retorna a lista referenciada por uma entrada do WAL, criando-a se
+// create for verdadeiro. Entradas de versões antigas (sem UUID) usam o nome
+func (l *RemoteList) entryList(entry LogEntry, create bool) *listState {
+	listUUID := entry.ListUUID
+	if listUUID == uuid.Nil {
+		listUUID = l.nameToUUID[entry.ListName]
+	}
+	if list, exists := l.lists[listUUID]; exists {
+		return list
+	}
+	if !create {
+		return nil
+	}
+
+	if listUUID == uuid.Nil {
+		listUUID = uuid.New()
+	}
+	createdAt := entry.CreatedAt
+	if createdAt == 0 {
+		createdAt = entry.Timestamp
+	}
+	list := l.createList(listUUID, entry.ListName, createdAt)
+	l.logf("Nova lista criada: '%s' UUID: %s\n", entry.ListName, listUUID)
+	return list
+}
+
+// applyEntry aplica uma operação do WAL ao estado em memória. É usada tanto pelas
+// RPCs (depois de escrever no WAL) quanto pelo replay, garantindo o mesmo resultado
+func (l *RemoteList) applyEntry(entry LogEntry) {
+	if entry.Operation == "TX" {
+		// As operações da transação recebem o LSN do registro TX
+		for _, op := range entry.Ops {
+			op.LSN = entry.LSN
+			op.Timestamp = entry.Timestamp
+			l.applyEntry(op)
+		}
+		return
+	}
+	if isSortedSetOp(entry.Operation) {
+		l.applySortedSetEntry(entry)
+		return
+	}
+
+	create := entry.Operation == "APPEND" || entry.Operation == "PUSH_FRONT" || entry.Operation == "SET_CAP"
+	list := l.entryList(entry, create)
+	if list == nil {
+		return
+	}
+	// A versão da lista é o LSN da última operação que a alterou. No preparo de
+	// uma transação ainda não há LSN: as verificações de versão usam a de antes dela
+	if !l.staging {
+		list.version = entry.LSN
+	}
+
+	switch entry.Operation {
+	case "APPEND":
+		list.items.PushBackExpiring(entry.Value, entry.ExpiresAt)
+		list.trackExpiry(entry.ExpiresAt)
+		list.enforceCap(false)
+		l.signalWaiter(list.name)
+	case "REMOVE":
+		if list.items.Len() > 0 {
+			list.items.PopBack()
+		}
+	case "PUSH_FRONT":
+		list.items.PushFrontExpiring(entry.Value, entry.ExpiresAt)
+		list.trackExpiry(entry.ExpiresAt)
+		list.enforceCap(true)
+		l.signalWaiter(list.name)
+	case "POP_FRONT":
+		if list.items.Len() > 0 {
+			list.items.PopFront()
+		}
+	case "INSERT":
+		if entry.Index >= 0 && entry.Index <= list.items.Len() {
+			list.items.Insert(entry.Index, entry.Value)
+			// No índice 0 o elemento novo está no início: o excesso sai do fim, como
+			// em PUSH_FRONT. Nas demais posições sai do início, sem atingir o novo
+			list.enforceCap(entry.Index == 0)
+			l.signalWaiter(list.name)
+		}
+	case "SET":
+		if entry.Index >= 0 && entry.Index < list.items.Len() {
+			list.items.Set(entry.Index, entry.Value)
+		}
+	case "REMOVE_AT":
+		if entry.Index >= 0 && entry.Index < list.items.Len() {
+			list.items.RemoveAt(entry.Index)
+		}
+	case "DELETE_LIST", "EXPIRE_LIST":
+		delete(l.nameToUUID, list.name)
+		delete(l.lists, list.uuid)
+	case "RENAME_LIST":
+		delete(l.nameToUUID, list.name)
+		list.name = entry.NewName
+		l.nameToUUID[list.name] = list.uuid
+		if list.items.Len() > 0 {
+			l.signalWaiter(list.name)
+		}
+	case "CLEAR":
+		list.items.Clear()
+		list.nextExpiry = 0
+	case "SET_TTL":
+		list.expiresAt = entry.ExpiresAt
+	case "EXPIRE":
+		list.items.RemoveExpired(entry.ExpiresAt)
+		list.nextExpiry = list.items.NextExpiry()
+	case "SET_CAP":
+		list.maxLength = entry.MaxLength
+		list.capPolicy = entry.CapPolicy
+		list.enforceCap(false)
+	case "SORT", "REVERSE", "DEDUPE", "TRIM":
+		list.items.Keep(transformPositions(&list.items, entry))
+	case "SET_INDEX":
+		if entry.Indexed {
+			list.items.EnableIndex()
+		} else {
+			list.items.DisableIndex()
+		}
+	}
+}
+
+// commit escreve a entrada no WAL e a aplica ao estado em memória. Deve ser chamado
+// com o write lock; o LSN retornado é passado para waitDurable após liberar o lock
+func (l *RemoteList) commit(entry *LogEntry) (uint64, error) {
+	if l.staging {
+		// Transação em preparo: a entrada só é registrada e aplicada à cópia. Não há
+		// LSN; a posição no TX (nunca 0) distingue a escrita de um valor descartado
+		l.txEntries = append(l.txEntries, *entry)
+		l.applyEntry(*entry)
+		return uint64(len(l.txEntries)), nil
+	}
 
-	err := l.writeWAL("APPEND", args.ListName, args.Value)
+	lsn, err := l.writeWAL(entry)
+	if err != nil {
+		return 0, fmt.Errorf("erro ao escrever WAL: %w", err)
+	}
+
+	l.applyEntry(*entry)
+	return lsn, nil
+}
+
+// logf imprime o log de uma operação; fica em silêncio durante o preparo de uma
+// transação, que pode ser abortada
+func (l *RemoteList) logf(format string, args ...interface{}) {
+	if l.staging {
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// waitDurable espera, fora do lock, o group commit do lote que contém lsn
+func (l *RemoteList) waitDurable(lsn uint64) error {
+	err := l.wal.waitDurable(lsn)
 	if err != nil {
 		return fmt.Errorf("erro ao escrever WAL: %v", err)
 	}
+	return nil
+}
+
+// appendLocked adiciona o valor ao final da lista, criando-a se não existir.
+// Os métodos *Locked validam, escrevem no WAL e aplicam a operação; devem ser
+// chamados com o write lock e o LSN retornado é passado para waitDurable.
+// Em uma lista cheia com CapDropNewest o valor é descartado e o LSN é 0
+func (l *RemoteList) appendLocked(name string, value Value, ttl time.Duration) (uint64, error) {
+	admitted, err := l.admitLocked(name)
+	if !admitted {
+		return 0, err
+	}
 
-	listUUID := l.getOrCreateListUUID(args.ListName)
-	l.lists[listUUID] = append(l.lists[listUUID], args.Value)
-	fmt.Printf("Lista '%s': %v\n", args.ListName, l.lists[listUUID])
+	entry := l.newListEntry("APPEND", name)
+	entry.Value = value
+	entry.ExpiresAt = expiryFromTTL(ttl)
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Lista '%s': %v\n", name, l.lists[entry.ListUUID].items)
+	return lsn, nil
+}
+
+func (l *RemoteList) Append(args AppendArgs, reply *bool) error {
+	l.lockLists(args.ListName) // Write lock - acesso exclusivo (bloqueia leitores e escritores)
+	lsn, err := l.appendLocked(args.ListName, args.Value, args.TTL)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if lsn == 0 {
+		*reply = false // lista cheia (CapDropNewest): valor descartado
+		return nil
+	}
+
+	// Group commit: espera o fsync do lote fora do lock
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
 
 	*reply = true
 	return nil
 }
 
-func (l *RemoteList) Get(args GetArgs, reply *int) error {
-	l.mu.RLock() // Read lock - permite múltiplos leitores
+// getLocked retorna o valor na posição index. Deve ser chamado com o lock
+func (l *RemoteList) getLocked(name string, index int) (Value, error) {
+	list, err := l.getList(name)
+	if err != nil {
+		return Value{}, err
+	}
+	if index < 0 || index >= list.items.Len() {
+		return Value{}, errors.New("index out of bounds")
+	}
+	return list.items.At(index), nil
+}
+
+func (l *RemoteList) Get(args GetArgs, reply *Value) error {
+	l.rlockLists(args.ListName) // Read lock - permite múltiplos leitores
 	defer l.mu.RUnlock()
 
-	*reply = 0
+	value, err := l.getLocked(args.ListName, args.Index)
+	*reply = value
+	return err
+}
 
-	listUUID, exists := l.nameToUUID[args.ListName]
-	if !exists {
-		return errors.New("list not found")
+// removeLocked remove e retorna o último elemento (ou o primeiro, se front)
+func (l *RemoteList) removeLocked(name string, front bool) (Value, uint64, error) {
+	list, err := l.getList(name)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	if list.items.Len() == 0 {
+		return Value{}, 0, errors.New("empty list")
 	}
+	return l.popLocked(list, front)
+}
 
-	list := l.lists[listUUID]
-	if args.Index < 0 || args.Index >= len(list) {
-		return errors.New("index out of bounds")
+func (l *RemoteList) Remove(args RemoveArgs, reply *Value) error {
+	*reply = Value{}
+
+	l.lockLists(args.ListName)
+	removedValue, lsn, err := l.removeLocked(args.ListName, false)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Group commit: espera o fsync do lote fora do lock
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
 	}
 
-	*reply = list[args.Index]
+	*reply = removedValue
 	return nil
 }
 
-func (l *RemoteList) Remove(args RemoveArgs, reply *int) error {
-	l.mu.Lock()
-	defer l.mu.Unlock()
+// pushFrontLocked adiciona o valor ao início da lista, criando-a se não existir
+func (l *RemoteList) pushFrontLocked(name string, value Value) (uint64, error) {
+	admitted, err := l.admitLocked(name)
+	if !admitted {
+		return 0, err
+	}
+
+	entry := l.newListEntry("PUSH_FRONT", name)
+	entry.Value = value
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Lista '%s': %v\n", name, l.lists[entry.ListUUID].items)
+	return lsn, nil
+}
 
-	*reply = 0
+// PushFront adiciona o valor ao início da lista, criando-a se não existir
+func (l *RemoteList) PushFront(args PushFrontArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.pushFrontLocked(args.ListName, args.Value)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if lsn == 0 {
+		*reply = false // lista cheia (CapDropNewest): valor descartado
+		return nil
+	}
 
-	listUUID, exists := l.nameToUUID[args.ListName]
-	if !exists {
-		return errors.New("list not found")
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
 	}
 
-	list := l.lists[listUUID]
-	if len(list) == 0 {
-		return errors.New("empty list")
+	*reply = true
+	return nil
+}
+
+// PopFront remove e retorna o primeiro elemento: com Append forma uma fila FIFO
+func (l *RemoteList) PopFront(args PopFrontArgs, reply *Value) error {
+	*reply = Value{}
+
+	l.lockLists(args.ListName)
+	removedValue, lsn, err := l.removeLocked(args.ListName, true)
+	l.mu.Unlock()
+	if err != nil {
+		return err
 	}
 
-	// Captura valor antes de remover
-	removedValue := list[len(list)-1]
-	err := l.writeWAL("REMOVE", args.ListName, removedValue)
+	err = l.waitDurable(lsn)
 	if err != nil {
-		return fmt.Errorf("erro ao escrever WAL: %v", err)
+		return err
 	}
 
 	*reply = removedValue
-	l.lists[listUUID] = list[:len(list)-1]
-	fmt.Printf("Lista '%s': %v (removido: %d)\n", args.ListName, l.lists[listUUID], *reply)
 	return nil
 }
 
-func (l *RemoteList) Size(args SizeArgs, reply *int) error {
-	l.mu.RLock()
-	defer l.mu.RUnlock()
-
-	listUUID, exists := l.nameToUUID[args.ListName]
+// getList retorna a lista pelo nome. Deve ser chamado com o lock
+func (l *RemoteList) getList(name string) (*listState, error) {
+	listUUID, exists := l.nameToUUID[name]
 	if !exists {
-		*reply = 0
+		return nil, errors.New("list not found")
+	}
+	return l.lists[listUUID], nil
+}
+
+// insertLocked insere o valor na posição index (0 <= index <= tamanho)
+func (l *RemoteList) insertLocked(name string, index int, value Value) (uint64, error) {
+	list, err := l.getList(name)
+	if err != nil {
+		return 0, err
+	}
+	if index < 0 || index > list.items.Len() {
+		return 0, errors.New("index out of bounds")
+	}
+	admitted, err := l.admitLocked(name)
+	if !admitted {
+		return 0, err
+	}
+
+	entry := l.newListEntry("INSERT", name)
+	entry.Index = index
+	entry.Value = value
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Lista '%s': %v\n", name, list.items)
+	return lsn, nil
+}
+
+// Insert insere o valor na posição Index, deslocando os seguintes; Index igual ao
+// tamanho da lista equivale a Append
+func (l *RemoteList) Insert(args InsertArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.insertLocked(args.ListName, args.Index, args.Value)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if lsn == 0 {
+		*reply = false // lista cheia (CapDropNewest): valor descartado
 		return nil
 	}
 
-	*reply = len(l.lists[listUUID])
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
+
+// setLocked substitui o valor na posição index e retorna o anterior
+func (l *RemoteList) setLocked(name string, index int, value Value) (Value, uint64, error) {
+	list, err := l.getList(name)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	if index < 0 || index >= list.items.Len() {
+		return Value{}, 0, errors.New("index out of bounds")
+	}
+
+	oldValue := list.items.At(index)
+	entry := l.newListEntry("SET", name)
+	entry.Index = index
+	entry.Value = value
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	l.logf("Lista '%s': %v\n", name, list.items)
+	return oldValue, lsn, nil
+}
+
+// Set substitui o valor na posição Index e retorna o valor anterior
+func (l *RemoteList) Set(args SetArgs, reply *Value) error {
+	*reply = Value{}
+
+	l.lockLists(args.ListName)
+	oldValue, lsn, err := l.setLocked(args.ListName, args.Index, args.Value)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = oldValue
+	return nil
+}
+
+// removeAtLocked remove e retorna o valor na posição index
+func (l *RemoteList) removeAtLocked(name string, index int) (Value, uint64, error) {
+	list, err := l.getList(name)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	if index < 0 || index >= list.items.Len() {
+		return Value{}, 0, errors.New("index out of bounds")
+	}
+
+	removedValue := list.items.At(index)
+	entry := l.newListEntry("REMOVE_AT", name)
+	entry.Index = index
+	entry.Value = removedValue
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	l.logf("Lista '%s': %v (removido: %v)\n", name, list.items, removedValue)
+	return removedValue, lsn, nil
+}
+
+// RemoveAt remove e retorna o valor na posição Index, deslocando os seguintes
+func (l *RemoteList) RemoveAt(args RemoveAtArgs, reply *Value) error {
+	*reply = Value{}
+
+	l.lockLists(args.ListName)
+	removedValue, lsn, err := l.removeAtLocked(args.ListName, args.Index)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = removedValue
+	return nil
+}
+
+// sizeLocked retorna o tamanho da lista; lista inexistente tem tamanho 0
+func (l *RemoteList) sizeLocked(name string) int {
+	listUUID, exists := l.nameToUUID[name]
+	if !exists {
+		return 0
+	}
+	return l.lists[listUUID].items.Len()
+}
+
+func (l *RemoteList) Size(args SizeArgs, reply *int) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	*reply = l.sizeLocked(args.ListName)
 	return nil
 }
 
 func (l *RemoteList) ListAll(args int, reply *ListAllReply) error {
-	l.mu.RLock()
+	l.rlockLists()
 	defer l.mu.RUnlock()
 
 	names := make([]string, 0, len(l.nameToUUID))
@@ -452,27 +1015,236 @@ func (l *RemoteList) ListAll(args int, reply *ListAllReply) error {
 	return nil
 }
 
+// deleteListLocked apaga a lista e seus elementos
+func (l *RemoteList) deleteListLocked(name string) (uint64, error) {
+	if _, exists := l.nameToUUID[name]; !exists {
+		return 0, errors.New("list not found")
+	}
+
+	entry := l.newListEntry("DELETE_LIST", name)
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Lista '%s' apagada\n", name)
+	return lsn, nil
+}
+
+// DeleteList apaga a lista e seus elementos; o nome fica livre para uma lista nova
+func (l *RemoteList) DeleteList(args DeleteListArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.deleteListLocked(args.ListName)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
+
+// renameListLocked troca o nome da lista; o nome novo não pode estar em uso
+func (l *RemoteList) renameListLocked(name, newName string) (uint64, error) {
+	if newName == "" {
+		return 0, errors.New("invalid list name")
+	}
+	if _, exists := l.nameToUUID[name]; !exists {
+		return 0, errors.New("list not found")
+	}
+	if _, exists := l.nameToUUID[newName]; exists {
+		return 0, errors.New("list already exists")
+	}
+
+	entry := l.newListEntry("RENAME_LIST", name)
+	entry.NewName = newName
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Lista '%s' renomeada para '%s'\n", name, newName)
+	return lsn, nil
+}
+
+// RenameList troca o nome da lista mantendo UUID, data de criação e elementos
+func (l *RemoteList) RenameList(args RenameListArgs, reply *bool) error {
+	l.lockLists(args.ListName, args.NewName)
+	lsn, err := l.renameListLocked(args.ListName, args.NewName)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
+
+// clearLocked remove todos os elementos da lista
+func (l *RemoteList) clearLocked(name string) (uint64, error) {
+	if _, exists := l.nameToUUID[name]; !exists {
+		return 0, errors.New("list not found")
+	}
+
+	entry := l.newListEntry("CLEAR", name)
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Lista '%s': %v\n", name, l.lists[entry.ListUUID].items)
+	return lsn, nil
+}
+
+// Clear remove todos os elementos, mantendo a lista (e o UUID) existente
+func (l *RemoteList) Clear(args ClearArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.clearLocked(args.ListName)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
+
+// info preenche reply com os dados da lista. Deve ser chamado com o lock
+func (list *listState) info(reply *ListInfo) {
+	reply.UUID = list.uuid
+	reply.Name = list.name
+	reply.CreatedAtUnix = list.createdAt
+	reply.Size = list.items.Len()
+	reply.Version = list.version
+	reply.ExpiresAtMillis = list.expiresAt
+	reply.MaxLength = list.maxLength
+	reply.CapPolicy = list.capPolicy
+	reply.Indexed = list.items.Indexed()
+}
+
+// Info retorna UUID, data de criação, tamanho, versão e expiração de uma lista a
+// partir do nome
+func (l *RemoteList) Info(args InfoArgs, reply *ListInfo) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	listUUID, exists := l.nameToUUID[args.ListName]
+	if !exists {
+		return errors.New("list not found")
+	}
+
+	l.lists[listUUID].info(reply)
+	return nil
+}
+
+// LookupUUID encontra uma lista pelo UUID, que não muda entre reinícios do servidor
+func (l *RemoteList) LookupUUID(args LookupUUIDArgs, reply *ListInfo) error {
+	l.rlockLists()
+	defer l.mu.RUnlock()
+
+	list, exists := l.lists[args.UUID]
+	if !exists {
+		return errors.New("list not found")
+	}
+
+	list.info(reply)
+	return nil
+}
+
+// Close para as rotinas em background, grava um snapshot final e fecha o WAL.
+// Com o snapshot final, o próximo início não precisa reaplicar o WAL.
+// Deve ser chamado depois que o servidor parou de atender chamadas
+func (l *RemoteList) Close() error {
+	l.closeOnce.Do(func() {
+		fmt.Println("\n=== Encerrando RemoteList ===")
+		close(l.stopCh)
+		l.stopWaiting()
+		l.bgWG.Wait()
+
+		err := l.createSnapshot()
+		if err != nil {
+			fmt.Printf("Erro ao criar snapshot final: %v\n", err)
+			l.closeErr = err
+		}
+
+		err = l.wal.close()
+		if err != nil {
+			fmt.Printf("Erro ao fechar WAL: %v\n", err)
+			if l.closeErr == nil {
+				l.closeErr = err
+			}
+		}
+		fmt.Println("RemoteList encerrada")
+	})
+	return l.closeErr
+}
+
+// NewRemoteList cria a lista com a configuração padrão e as variáveis de ambiente
+// REMOTELIST_*; erros de configuração ou de recuperação encerram o processo
 func NewRemoteList() *RemoteList {
-	os.MkdirAll("data", 0755)
+	config := DefaultConfig()
+	err := config.ApplyEnv()
+	if err != nil {
+		panic(fmt.Sprintf("Erro de configuração: %v", err))
+	}
 
-	walFile, err := os.OpenFile("data/wal.log", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	list, err := NewRemoteListWithConfig(config)
 	if err != nil {
-		panic(fmt.Sprintf("Erro ao abrir WAL: %v", err))
+		panic(fmt.Sprintf("Erro ao iniciar RemoteList: %v", err))
+	}
+	return list
+}
+
+// NewRemoteListWithConfig recupera o estado do diretório de dados da configuração,
+// abre o WAL e inicia o snapshot e a expiração automáticos
+func NewRemoteListWithConfig(config Config) (*RemoteList, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("erro de configuração: %v", err)
+	}
+
+	err = os.MkdirAll(config.DataDir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório de dados: %v", err)
 	}
 
 	list := &RemoteList{
 		nameToUUID: make(map[string]uuid.UUID),
-		lists:      make(map[uuid.UUID][]int),
+		lists:      make(map[uuid.UUID]*listState),
+		sortedSets: make(map[string]*sortedSetState),
 		currentLSN: 0,
-		walFile:    walFile,
+		config:     config,
+		stopCh:     make(chan struct{}),
+		waiters:    make(map[string][]*popWaiter),
+		waitStopCh: make(chan struct{}),
 	}
 
 	err = list.Recover()
 	if err != nil {
-		panic(fmt.Sprintf("Erro na recuperação: %v", err))
+		return nil, fmt.Errorf("erro na recuperação: %v", err)
+	}
+
+	// O WAL só é aberto depois do recovery: o segmento novo começa em currentLSN+1
+	list.wal, err = newWALWriter(config, list.currentLSN+1)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir WAL: %v", err)
 	}
 
-	list.startSnapshotRoutine(120)
+	fmt.Printf("Durabilidade do WAL: %s\n", config.Durability)
+	list.startSnapshotRoutine(config.SnapshotInterval)
+	list.startExpiryRoutine(config.ExpiryInterval)
 
-	return list
+	return list, nil
 }
@@ -0,0 +1,151 @@
+package remotelist
+
+type IndexOfArgs struct {
+	ListName  string
+	Value     Value
+	FromIndex int // primeira posição examinada; negativo conta a partir do fim
+}
+
+type LastIndexOfArgs struct {
+	ListName string
+	Value    Value
+}
+
+type ContainsArgs struct {
+	ListName string
+	Value    Value
+}
+
+type CountArgs struct {
+	ListName string
+	Value    Value
+}
+
+type SetValueIndexArgs struct {
+	ListName string
+	Enabled  bool
+}
+
+// As buscas tratam uma lista inexistente como vazia, como Size. Sem o índice de
+// valores elas percorrem a lista (O(n)); com ele, Contains e Count são O(1) e
+// IndexOf/LastIndexOf de um valor ausente retornam -1 sem percorrer a lista
+
+// IndexOf retorna a primeira posição >= FromIndex com o valor, ou -1
+func (l *RemoteList) IndexOf(args IndexOfArgs, reply *int) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	*reply = -1
+	list, err := l.getList(args.ListName)
+	if err != nil || (list.items.Indexed() && list.items.Count(args.Value) == 0) {
+		return nil
+	}
+
+	start, _ := normalizeRange(args.FromIndex, list.items.Len(), list.items.Len())
+	for i := start; i < list.items.Len(); i++ {
+		if list.items.At(i) == args.Value {
+			*reply = i
+			return nil
+		}
+	}
+	return nil
+}
+
+// LastIndexOf retorna a última posição com o valor, ou -1
+func (l *RemoteList) LastIndexOf(args LastIndexOfArgs, reply *int) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	*reply = -1
+	list, err := l.getList(args.ListName)
+	if err != nil || (list.items.Indexed() && list.items.Count(args.Value) == 0) {
+		return nil
+	}
+
+	for i := list.items.Len() - 1; i >= 0; i-- {
+		if list.items.At(i) == args.Value {
+			*reply = i
+			return nil
+		}
+	}
+	return nil
+}
+
+// Contains informa se o valor está na lista
+func (l *RemoteList) Contains(args ContainsArgs, reply *bool) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	*reply = false
+	list, err := l.getList(args.ListName)
+	if err != nil {
+		return nil
+	}
+	if list.items.Indexed() {
+		*reply = list.items.Count(args.Value) > 0
+		return nil
+	}
+	for i := 0; i < list.items.Len(); i++ {
+		if list.items.At(i) == args.Value {
+			*reply = true
+			return nil
+		}
+	}
+	return nil
+}
+
+// Count retorna quantas vezes o valor aparece na lista
+func (l *RemoteList) Count(args CountArgs, reply *int) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	*reply = 0
+	list, err := l.getList(args.ListName)
+	if err != nil {
+		return nil
+	}
+	*reply = list.items.Count(args.Value)
+	return nil
+}
+
+// setValueIndexLocked ativa ou desativa o índice de valores da lista
+func (l *RemoteList) setValueIndexLocked(name string, enabled bool) (uint64, error) {
+	_, err := l.getList(name)
+	if err != nil {
+		return 0, err
+	}
+
+	entry := l.newListEntry("SET_INDEX", name)
+	entry.Indexed = enabled
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	if enabled {
+		l.logf("Lista '%s': índice de valores ativado\n", name)
+	} else {
+		l.logf("Lista '%s': índice de valores desativado\n", name)
+	}
+	return lsn, nil
+}
+
+// SetValueIndex ativa (ou desativa) o índice de valores da lista: uma contagem de
+// cada valor, mantida a cada escrita, que torna Contains e Count O(1). Custa memória
+// proporcional aos valores distintos; vale para listas grandes com buscas frequentes.
+// A configuração é persistida; o índice é reconstruído no recovery
+func (l *RemoteList) SetValueIndex(args SetValueIndexArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.setValueIndexLocked(args.ListName, args.Enabled)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
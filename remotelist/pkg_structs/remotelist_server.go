@@ -0,0 +1,137 @@
+package remotelist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+	"time"
+)
+
+// ErrServerClosed é retornado por Serve depois de Shutdown
+var ErrServerClosed = errors.New("remotelist: servidor encerrado")
+
+// Server expõe uma RemoteList via net/rpc e controla o encerramento gracioso:
+// Shutdown para de aceitar conexões, espera as chamadas em andamento e fecha a lista
+type Server struct {
+	list *RemoteList
+	rpcs *rpc.Server
+
+	mu           sync.Mutex
+	listener     net.Listener
+	conns        map[net.Conn]struct{}
+	shuttingDown bool
+	connWG       sync.WaitGroup
+}
+
+// NewServer registra a lista em um novo servidor RPC
+func NewServer(list *RemoteList) (*Server, error) {
+	rpcs := rpc.NewServer()
+	err := rpcs.Register(list)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		list:  list,
+		rpcs:  rpcs,
+		conns: make(map[net.Conn]struct{}),
+	}, nil
+}
+
+// Serve aceita conexões até Shutdown, atendendo cada cliente em uma goroutine
+func (s *Server) Serve(l net.Listener) error {
+	s.mu.Lock()
+	if s.shuttingDown {
+		s.mu.Unlock()
+		l.Close()
+		return ErrServerClosed
+	}
+	s.listener = l
+	s.mu.Unlock()
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			s.mu.Lock()
+			shuttingDown := s.shuttingDown
+			s.mu.Unlock()
+			if shuttingDown {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		if s.shuttingDown {
+			s.mu.Unlock()
+			conn.Close()
+			continue
+		}
+		s.conns[conn] = struct{}{}
+		s.connWG.Add(1)
+		s.mu.Unlock()
+
+		go func() { //goroutines permite multiplos clientes se conectarem
+			defer s.connWG.Done()
+			// ServeConn só retorna depois de enviar as respostas das chamadas em andamento
+			s.rpcs.ServeConn(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Shutdown encerra o servidor: fecha o listener, para de ler novas requisições,
+// espera as chamadas em andamento (até o fim de ctx) e fecha a lista, gerando o
+// snapshot final. Se ctx expirar, as conexões restantes são fechadas à força
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.shuttingDown {
+		s.mu.Unlock()
+		return errors.New("remotelist: Shutdown já chamado")
+	}
+	s.shuttingDown = true
+	// Chamadas bloqueantes (BlockingRemove, BlockingPopFront) retornam erro em vez
+	// de segurar o encerramento até o prazo
+	s.list.stopWaiting()
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	// Desbloqueia a leitura de cada conexão: o ServeConn deixa de aceitar
+	// requisições novas e termina depois de responder as pendentes
+	for conn := range s.conns {
+		conn.SetReadDeadline(time.Now())
+	}
+	s.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		s.connWG.Wait()
+		close(drained)
+	}()
+
+	var ctxErr error
+	select {
+	case <-drained:
+		fmt.Println("Conexões encerradas, chamadas em andamento concluídas")
+	case <-ctx.Done():
+		ctxErr = ctx.Err()
+		fmt.Printf("Aviso: prazo de encerramento esgotado (%v), fechando conexões restantes\n", ctxErr)
+		s.mu.Lock()
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.mu.Unlock()
+	}
+
+	err := s.list.Close()
+	if err != nil {
+		return err
+	}
+	return ctxErr
+}
@@ -0,0 +1,235 @@
+package remotelist
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// SortedSetMember é um membro de um conjunto ordenado com o seu score
+type SortedSetMember struct {
+	Member Value   `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// sortedSet guarda membros únicos ordenados por (score, membro): uma treap com o
+// tamanho de cada subárvore, o que dá inserção, remoção, rank e busca por score em
+// O(log n) esperado, e um mapa membro -> score para achar o score de um membro em O(1).
+// Membros com o mesmo score ficam na ordem de compareMembers
+type sortedSet struct {
+	root   *treapNode
+	scores map[Value]float64
+}
+
+type treapNode struct {
+	member      Value
+	score       float64
+	priority    uint32 // a treap é um heap pelas prioridades (aleatórias), o que a mantém balanceada
+	size        int    // nós nesta subárvore
+	left, right *treapNode
+}
+
+func newSortedSet() *sortedSet {
+	return &sortedSet{scores: make(map[Value]float64)}
+}
+
+func (s *sortedSet) Len() int {
+	return len(s.scores)
+}
+
+// Score retorna o score do membro
+func (s *sortedSet) Score(member Value) (float64, bool) {
+	score, exists := s.scores[member]
+	return score, exists
+}
+
+// Add insere o membro ou atualiza o seu score; retorna se o membro é novo
+func (s *sortedSet) Add(member Value, score float64) bool {
+	old, exists := s.scores[member]
+	if exists {
+		if old == score {
+			return false
+		}
+		s.root = treapDelete(s.root, member, old)
+	}
+	s.scores[member] = score
+
+	node := &treapNode{member: member, score: score, priority: rand.Uint32(), size: 1}
+	left, right := treapSplit(s.root, score, member)
+	s.root = treapMerge(treapMerge(left, node), right)
+	return !exists
+}
+
+// Remove retira o membro; retorna se ele existia
+func (s *sortedSet) Remove(member Value) bool {
+	score, exists := s.scores[member]
+	if !exists {
+		return false
+	}
+	delete(s.scores, member)
+	s.root = treapDelete(s.root, member, score)
+	return true
+}
+
+// Rank retorna a posição do membro na ordem crescente (0 = menor score)
+func (s *sortedSet) Rank(member Value) (int, bool) {
+	score, exists := s.scores[member]
+	if !exists {
+		return 0, false
+	}
+	return s.countBefore(score, member), true
+}
+
+// countBefore conta os membros que vêm antes de (score, member)
+func (s *sortedSet) countBefore(score float64, member Value) int {
+	count := 0
+	for node := s.root; node != nil; {
+		if treapLess(node.score, node.member, score, member) {
+			count += node.left.sizeOf() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return count
+}
+
+// CountBelow conta os membros com score < score (ou <= score, com inclusive)
+func (s *sortedSet) CountBelow(score float64, inclusive bool) int {
+	count := 0
+	for node := s.root; node != nil; {
+		if node.score < score || (inclusive && node.score == score) {
+			count += node.left.sizeOf() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+	return count
+}
+
+// Slice retorna os membros das posições [start, end), em ordem crescente
+func (s *sortedSet) Slice(start, end int) []SortedSetMember {
+	if start >= end {
+		return nil
+	}
+	members := make([]SortedSetMember, 0, end-start)
+	treapCollect(s.root, start, end, &members)
+	return members
+}
+
+// Members retorna todos os membros em ordem crescente
+func (s *sortedSet) Members() []SortedSetMember {
+	return s.Slice(0, s.Len())
+}
+
+func (n *treapNode) sizeOf() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *treapNode) update() {
+	n.size = n.left.sizeOf() + n.right.sizeOf() + 1
+}
+
+// treapLess ordena os nós por score e, no empate, pelo membro
+func treapLess(score float64, member Value, otherScore float64, otherMember Value) bool {
+	if score != otherScore {
+		return score < otherScore
+	}
+	return compareMembers(member, otherMember) < 0
+}
+
+// compareMembers é a ordem de compareValues completada para ser total e coerente
+// com ==: valores que compareValues considera iguais mas que são diferentes (1 e
+// 1.0, 0.0 e -0.0) são desempatados por tipo, bits do número e dados. Sem isso a
+// busca na treap poderia descer para o lado errado de um membro empatado
+func compareMembers(a, b Value) int {
+	if c := compareValues(a, b); c != 0 {
+		return c
+	}
+	switch {
+	case a.kind != b.kind:
+		if a.kind < b.kind {
+			return -1
+		}
+		return 1
+	case a.num != b.num:
+		if a.num < b.num {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a.data, b.data)
+}
+
+// treapSplit divide a árvore nos nós antes de (score, member) e nos demais
+func treapSplit(node *treapNode, score float64, member Value) (*treapNode, *treapNode) {
+	if node == nil {
+		return nil, nil
+	}
+	if treapLess(node.score, node.member, score, member) {
+		left, right := treapSplit(node.right, score, member)
+		node.right = left
+		node.update()
+		return node, right
+	}
+	left, right := treapSplit(node.left, score, member)
+	node.left = right
+	node.update()
+	return left, node
+}
+
+// treapMerge junta duas árvores em que todos os nós de left vêm antes dos de right
+func treapMerge(left, right *treapNode) *treapNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	if left.priority > right.priority {
+		left.right = treapMerge(left.right, right)
+		left.update()
+		return left
+	}
+	right.left = treapMerge(left, right.left)
+	right.update()
+	return right
+}
+
+// treapDelete remove o nó (score, member), que deve existir na árvore
+func treapDelete(node *treapNode, member Value, score float64) *treapNode {
+	if node == nil {
+		return nil
+	}
+	if node.member == member {
+		return treapMerge(node.left, node.right)
+	}
+	if treapLess(score, member, node.score, node.member) {
+		node.left = treapDelete(node.left, member, score)
+	} else {
+		node.right = treapDelete(node.right, member, score)
+	}
+	node.update()
+	return node
+}
+
+// treapCollect adiciona a members os nós das posições [start, end) da subárvore,
+// descendo só nos ramos que têm posições do intervalo
+func treapCollect(node *treapNode, start, end int, members *[]SortedSetMember) {
+	if node == nil || start >= end {
+		return
+	}
+	leftSize := node.left.sizeOf()
+	if start < leftSize {
+		treapCollect(node.left, start, min(end, leftSize), members)
+	}
+	if start <= leftSize && leftSize < end {
+		*members = append(*members, SortedSetMember{Member: node.member, Score: node.score})
+	}
+	if end > leftSize+1 {
+		treapCollect(node.right, max(start-leftSize-1, 0), end-leftSize-1, members)
+	}
+}
@@ -0,0 +1,406 @@
+package remotelist
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+type SortedSetAddArgs struct {
+	SetName   string
+	Member    Value
+	Score     float64
+	Increment bool // soma Score ao score atual do membro (0 se ele não existir)
+}
+
+type SortedSetRemoveArgs struct {
+	SetName string
+	Member  Value
+}
+
+type SortedSetScoreArgs struct {
+	SetName string
+	Member  Value
+}
+
+type SortedSetRankArgs struct {
+	SetName    string
+	Member     Value
+	Descending bool // rank 0 = maior score
+}
+
+type SortedSetRangeArgs struct {
+	SetName    string
+	Start      int  // posições como em GetRange: negativas contam a partir do fim
+	End        int  // exclusivo
+	ToEnd      bool // ignora End e vai até o último membro
+	Descending bool // posições na ordem decrescente de score
+}
+
+type SortedSetRangeByScoreArgs struct {
+	SetName    string
+	Min        float64 // inclusivo; pode ser -Inf
+	Max        float64 // inclusivo; pode ser +Inf
+	Descending bool
+	Offset     int // membros do intervalo pulados, na ordem pedida
+	Limit      int // <= 0: sem limite
+}
+
+type SortedSetSizeArgs struct {
+	SetName string
+}
+
+type DeleteSortedSetArgs struct {
+	SetName string
+}
+
+type SortedSetRangeReply struct {
+	Members []SortedSetMember
+	Size    int    // quantidade de membros do conjunto
+	Version uint64 // versão do conjunto no momento da leitura
+}
+
+// SortedSetSnapshot é o estado de um conjunto ordenado dentro de um snapshot
+type SortedSetSnapshot struct {
+	Name      string            `json:"name"`
+	CreatedAt int64             `json:"created_at"`
+	Version   uint64            `json:"version"`
+	Members   []SortedSetMember `json:"members"` // em ordem crescente de score
+}
+
+// sortedSetState é um conjunto ordenado em memória, indexado pelo nome em
+// RemoteList.sortedSets. Conjuntos ordenados têm o próprio espaço de nomes: um
+// conjunto e uma lista podem ter o mesmo nome
+type sortedSetState struct {
+	name      string
+	createdAt int64
+	version   uint64 // LSN da última operação que alterou o conjunto
+	members   *sortedSet
+}
+
+// Os conjuntos ordenados são gravados no WAL com as operações SORTED_SET_ADD (com
+// o score final, já somado em Increment), SORTED_SET_REMOVE e DELETE_SORTED_SET;
+// ListName é o nome do conjunto e Value o membro
+func isSortedSetOp(operation string) bool {
+	return operation == "SORTED_SET_ADD" || operation == "SORTED_SET_REMOVE" || operation == "DELETE_SORTED_SET"
+}
+
+// applySortedSetEntry aplica uma operação de conjunto ordenado (ver applyEntry)
+func (l *RemoteList) applySortedSetEntry(entry LogEntry) {
+	set, exists := l.sortedSets[entry.ListName]
+	if !exists {
+		if entry.Operation != "SORTED_SET_ADD" {
+			return
+		}
+		createdAt := entry.CreatedAt
+		if createdAt == 0 {
+			createdAt = entry.Timestamp
+		}
+		set = l.createSortedSet(entry.ListName, createdAt)
+		l.logf("Novo conjunto ordenado criado: '%s'\n", entry.ListName)
+	}
+	set.version = entry.LSN
+
+	switch entry.Operation {
+	case "SORTED_SET_ADD":
+		set.members.Add(entry.Value, entry.Score)
+	case "SORTED_SET_REMOVE":
+		set.members.Remove(entry.Value)
+	case "DELETE_SORTED_SET":
+		delete(l.sortedSets, set.name)
+	}
+}
+
+// createSortedSet registra um conjunto ordenado vazio
+func (l *RemoteList) createSortedSet(name string, createdAt int64) *sortedSetState {
+	set := &sortedSetState{name: name, createdAt: createdAt, members: newSortedSet()}
+	l.sortedSets[name] = set
+	return set
+}
+
+func (l *RemoteList) getSortedSet(name string) (*sortedSetState, error) {
+	set, exists := l.sortedSets[name]
+	if !exists {
+		return nil, errors.New("sorted set not found")
+	}
+	return set, nil
+}
+
+// checkScore rejeita NaN e infinitos, que não têm representação no WAL (JSON)
+func checkScore(score float64) error {
+	if math.IsNaN(score) || math.IsInf(score, 0) {
+		return errors.New("score must be a finite number")
+	}
+	return nil
+}
+
+// sortedSetAddLocked adiciona o membro (criando o conjunto se não existir) e
+// retorna o score gravado
+func (l *RemoteList) sortedSetAddLocked(args SortedSetAddArgs) (float64, uint64, error) {
+	score := args.Score
+	if args.Increment {
+		if set, exists := l.sortedSets[args.SetName]; exists {
+			current, _ := set.members.Score(args.Member)
+			score += current
+		}
+	}
+	err := checkScore(score)
+	if err != nil {
+		return 0, 0, err
+	}
+	if score == 0 {
+		score = 0 // -0 viraria 0 no replay (omitempty)
+	}
+
+	entry := LogEntry{Operation: "SORTED_SET_ADD", ListName: args.SetName, Value: args.Member, Score: score}
+	if _, exists := l.sortedSets[args.SetName]; !exists {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, 0, err
+	}
+	l.logf("Conjunto ordenado '%s': %v = %v\n", args.SetName, args.Member, score)
+	return score, lsn, nil
+}
+
+// SortedSetAdd adiciona o membro com o score (ou atualiza o score de um membro
+// existente) e retorna o score gravado. Com Increment, soma Score ao atual
+func (l *RemoteList) SortedSetAdd(args SortedSetAddArgs, reply *float64) error {
+	err := checkScore(args.Score)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	score, lsn, err := l.sortedSetAddLocked(args)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = score
+	return nil
+}
+
+// sortedSetRemoveLocked retira o membro; retorna lsn 0 se ele não existia
+func (l *RemoteList) sortedSetRemoveLocked(name string, member Value) (bool, uint64, error) {
+	set, err := l.getSortedSet(name)
+	if err != nil {
+		return false, 0, err
+	}
+	if _, exists := set.members.Score(member); !exists {
+		return false, 0, nil
+	}
+
+	entry := LogEntry{Operation: "SORTED_SET_REMOVE", ListName: name, Value: member}
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return false, 0, err
+	}
+	l.logf("Conjunto ordenado '%s': %v removido\n", name, member)
+	return true, lsn, nil
+}
+
+// SortedSetRemove retira o membro do conjunto; reply indica se ele existia
+func (l *RemoteList) SortedSetRemove(args SortedSetRemoveArgs, reply *bool) error {
+	l.mu.Lock()
+	removed, lsn, err := l.sortedSetRemoveLocked(args.SetName, args.Member)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if removed {
+		err = l.waitDurable(lsn)
+		if err != nil {
+			return err
+		}
+	}
+
+	*reply = removed
+	return nil
+}
+
+// SortedSetScore retorna o score do membro
+func (l *RemoteList) SortedSetScore(args SortedSetScoreArgs, reply *float64) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	set, err := l.getSortedSet(args.SetName)
+	if err != nil {
+		return err
+	}
+	score, exists := set.members.Score(args.Member)
+	if !exists {
+		return errors.New("member not found")
+	}
+
+	*reply = score
+	return nil
+}
+
+// SortedSetRank retorna a posição do membro na ordem de score (crescente, ou
+// decrescente com Descending), ou -1 se ele não estiver no conjunto
+func (l *RemoteList) SortedSetRank(args SortedSetRankArgs, reply *int) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	*reply = -1
+	set, exists := l.sortedSets[args.SetName]
+	if !exists {
+		return nil
+	}
+	rank, exists := set.members.Rank(args.Member)
+	if !exists {
+		return nil
+	}
+	if args.Descending {
+		rank = set.members.Len() - 1 - rank
+	}
+
+	*reply = rank
+	return nil
+}
+
+// SortedSetRange retorna os membros das posições [Start, End) na ordem de score
+func (l *RemoteList) SortedSetRange(args SortedSetRangeArgs, reply *SortedSetRangeReply) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	set, err := l.getSortedSet(args.SetName)
+	if err != nil {
+		return err
+	}
+
+	size := set.members.Len()
+	end := args.End
+	if args.ToEnd {
+		end = size
+	}
+	start, end := normalizeRange(args.Start, end, size)
+	if args.Descending {
+		// Posições na ordem decrescente são as posições size-end..size-start da crescente
+		start, end = size-end, size-start
+	}
+
+	reply.Members = set.members.Slice(start, end)
+	if args.Descending {
+		reverseMembers(reply.Members)
+	}
+	reply.Size = size
+	reply.Version = set.version
+	return nil
+}
+
+// SortedSetRangeByScore retorna os membros com Min <= score <= Max, paginados por
+// Offset e Limit
+func (l *RemoteList) SortedSetRangeByScore(args SortedSetRangeByScoreArgs, reply *SortedSetRangeReply) error {
+	if math.IsNaN(args.Min) || math.IsNaN(args.Max) {
+		return errors.New("invalid score range")
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	set, err := l.getSortedSet(args.SetName)
+	if err != nil {
+		return err
+	}
+
+	// Posições [low, high) dos membros com score no intervalo
+	low := set.members.CountBelow(args.Min, false)
+	high := set.members.CountBelow(args.Max, true)
+	start, end := low+max(args.Offset, 0), high
+	if args.Descending {
+		start, end = low, high-max(args.Offset, 0)
+	}
+	if args.Limit > 0 && end-start > args.Limit {
+		if args.Descending {
+			start = end - args.Limit
+		} else {
+			end = start + args.Limit
+		}
+	}
+
+	reply.Members = set.members.Slice(start, end)
+	if args.Descending {
+		reverseMembers(reply.Members)
+	}
+	reply.Size = set.members.Len()
+	reply.Version = set.version
+	return nil
+}
+
+func reverseMembers(members []SortedSetMember) {
+	for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+		members[i], members[j] = members[j], members[i]
+	}
+}
+
+// SortedSetSize retorna a quantidade de membros; conjunto inexistente tem tamanho 0
+func (l *RemoteList) SortedSetSize(args SortedSetSizeArgs, reply *int) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	*reply = 0
+	if set, exists := l.sortedSets[args.SetName]; exists {
+		*reply = set.members.Len()
+	}
+	return nil
+}
+
+// ListSortedSets retorna os nomes dos conjuntos ordenados, em ordem alfabética
+func (l *RemoteList) ListSortedSets(args int, reply *[]string) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	names := make([]string, 0, len(l.sortedSets))
+	for name := range l.sortedSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	*reply = names
+	return nil
+}
+
+// deleteSortedSetLocked apaga o conjunto ordenado e seus membros
+func (l *RemoteList) deleteSortedSetLocked(name string) (uint64, error) {
+	_, err := l.getSortedSet(name)
+	if err != nil {
+		return 0, err
+	}
+
+	entry := LogEntry{Operation: "DELETE_SORTED_SET", ListName: name}
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	l.logf("Conjunto ordenado '%s' apagado\n", name)
+	return lsn, nil
+}
+
+// DeleteSortedSet apaga o conjunto ordenado; o nome fica livre para um conjunto novo
+func (l *RemoteList) DeleteSortedSet(args DeleteSortedSetArgs, reply *bool) error {
+	l.mu.Lock()
+	lsn, err := l.deleteSortedSetLocked(args.SetName)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
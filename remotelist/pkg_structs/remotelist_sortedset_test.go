@@ -0,0 +1,73 @@
+package remotelist
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// checkSortedSet compara a treap com o mapa de scores: mesmo tamanho, membros em
+// ordem e rank de cada membro igual à sua posição
+func checkSortedSet(t *testing.T, s *sortedSet) {
+	t.Helper()
+	if s.root.sizeOf() != len(s.scores) {
+		t.Fatalf("treap com %d nós e %d membros", s.root.sizeOf(), len(s.scores))
+	}
+
+	var want []SortedSetMember
+	for member, score := range s.scores {
+		want = append(want, SortedSetMember{Member: member, Score: score})
+	}
+	sort.Slice(want, func(i, j int) bool {
+		return treapLess(want[i].Score, want[i].Member, want[j].Score, want[j].Member)
+	})
+
+	got := s.Members()
+	if len(want) > 0 && !reflect.DeepEqual(got, want) {
+		t.Fatalf("membros %v, esperado %v", got, want)
+	}
+	for i, m := range want {
+		if rank, _ := s.Rank(m.Member); rank != i {
+			t.Fatalf("rank de %v = %d, esperado %d", m.Member, rank, i)
+		}
+	}
+}
+
+func TestSortedSetTiedMembersOfDifferentKinds(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		s := newSortedSet()
+		s.Add(IntValue(1), 5)
+		s.Add(FloatValue(1), 5)
+		s.Remove(FloatValue(1))
+		checkSortedSet(t, s)
+
+		s.Add(FloatValue(0), 5)
+		s.Add(FloatValue(math.Copysign(0, -1)), 5)
+		s.Remove(FloatValue(0))
+		checkSortedSet(t, s)
+	}
+}
+
+func TestSortedSetMixedKinds(t *testing.T) {
+	members := []Value{
+		IntValue(0), IntValue(1), IntValue(2),
+		FloatValue(0), FloatValue(math.Copysign(0, -1)), FloatValue(1), FloatValue(2), FloatValue(math.NaN()),
+		StringValue("1"), StringValue(""), BytesValue([]byte("1")), BytesValue(nil),
+	}
+	r := rand.New(rand.NewSource(1))
+	s := newSortedSet()
+	for i := 0; i < 20000; i++ {
+		member := members[r.Intn(len(members))]
+		if r.Intn(3) == 0 {
+			s.Remove(member)
+		} else {
+			s.Add(member, float64(r.Intn(3)))
+		}
+		if s.root.sizeOf() != len(s.scores) {
+			t.Fatalf("operação %d: treap com %d nós e %d membros", i, s.root.sizeOf(), len(s.scores))
+		}
+	}
+	checkSortedSet(t, s)
+}
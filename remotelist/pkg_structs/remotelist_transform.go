@@ -0,0 +1,171 @@
+package remotelist
+
+import (
+	"sort"
+	"strings"
+)
+
+type SortArgs struct {
+	ListName   string
+	Descending bool
+}
+
+type ReverseArgs struct {
+	ListName string
+}
+
+type DedupeArgs struct {
+	ListName string
+}
+
+type TrimArgs struct {
+	ListName string
+	Start    int  // índices como em GetRange: negativos contam a partir do fim
+	End      int  // exclusivo
+	ToEnd    bool // ignora End e mantém até o fim da lista
+}
+
+// As transformações são gravadas no WAL só com os parâmetros (SORT, REVERSE, DEDUPE,
+// TRIM com índices já normalizados). applyEntry recalcula a nova ordem a partir do
+// estado da lista, que no replay é o mesmo, então o resultado é idêntico. Cada
+// elemento mantém a sua expiração
+
+// compareValues define a ordem de Sort entre valores de qualquer tipo: números
+// (int e float, comparados pelo valor) vêm antes de strings, bytes e documentos JSON,
+// e dentro de cada um desses tipos a comparação é pelo conteúdo
+func compareValues(a, b Value) int {
+	aNumeric := a.kind == KindInt || a.kind == KindFloat
+	bNumeric := b.kind == KindInt || b.kind == KindFloat
+	switch {
+	case aNumeric && bNumeric:
+		return compareNumbers(a, b)
+	case aNumeric:
+		return -1
+	case bNumeric:
+		return 1
+	case a.kind < b.kind:
+		return -1
+	case a.kind > b.kind:
+		return 1
+	}
+	return strings.Compare(a.data, b.data)
+}
+
+// transformPositions calcula as posições que a lista mantém, na nova ordem, depois
+// de uma transformação. Sort é estável: valores iguais mantêm a ordem relativa
+func transformPositions(items *deque, entry LogEntry) []int {
+	n := items.Len()
+	var positions []int
+
+	switch entry.Operation {
+	case "SORT":
+		positions = make([]int, n)
+		for i := range positions {
+			positions[i] = i
+		}
+		sort.SliceStable(positions, func(i, j int) bool {
+			c := compareValues(items.At(positions[i]), items.At(positions[j]))
+			if entry.Descending {
+				return c > 0
+			}
+			return c < 0
+		})
+	case "REVERSE":
+		positions = make([]int, n)
+		for i := range positions {
+			positions[i] = n - 1 - i
+		}
+	case "DEDUPE":
+		seen := make(map[Value]bool, n)
+		for i := 0; i < n; i++ {
+			if !seen[items.At(i)] {
+				seen[items.At(i)] = true
+				positions = append(positions, i)
+			}
+		}
+	case "TRIM":
+		start, end := normalizeRange(entry.Index, entry.End, n)
+		for i := start; i < end; i++ {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// transformLocked grava e aplica uma transformação, retornando quantos elementos
+// foram removidos (Dedupe e Trim)
+func (l *RemoteList) transformLocked(entry LogEntry) (int, uint64, error) {
+	list, err := l.getList(entry.ListName)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	size := list.items.Len()
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, 0, err
+	}
+	l.logf("Lista '%s': %v\n", entry.ListName, list.items)
+	return size - list.items.Len(), lsn, nil
+}
+
+// transform executa uma transformação e espera a durabilidade
+func (l *RemoteList) transform(name string, fill func(entry *LogEntry, size int)) (int, error) {
+	l.lockLists(name)
+	entry := l.newListEntry("", name)
+	fill(&entry, l.sizeLocked(name))
+	removed, lsn, err := l.transformLocked(entry)
+	l.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// Sort ordena a lista (crescente, ou decrescente com Descending); ver compareValues
+func (l *RemoteList) Sort(args SortArgs, reply *bool) error {
+	_, err := l.transform(args.ListName, func(entry *LogEntry, size int) {
+		entry.Operation = "SORT"
+		entry.Descending = args.Descending
+	})
+	*reply = err == nil
+	return err
+}
+
+// Reverse inverte a ordem dos elementos
+func (l *RemoteList) Reverse(args ReverseArgs, reply *bool) error {
+	_, err := l.transform(args.ListName, func(entry *LogEntry, size int) {
+		entry.Operation = "REVERSE"
+	})
+	*reply = err == nil
+	return err
+}
+
+// Dedupe remove os valores repetidos, mantendo a primeira ocorrência de cada um,
+// e retorna quantos elementos foram removidos
+func (l *RemoteList) Dedupe(args DedupeArgs, reply *int) error {
+	removed, err := l.transform(args.ListName, func(entry *LogEntry, size int) {
+		entry.Operation = "DEDUPE"
+	})
+	*reply = removed
+	return err
+}
+
+// Trim mantém só lista[Start:End] e retorna quantos elementos foram removidos
+func (l *RemoteList) Trim(args TrimArgs, reply *int) error {
+	removed, err := l.transform(args.ListName, func(entry *LogEntry, size int) {
+		end := args.End
+		if args.ToEnd {
+			end = size
+		}
+		entry.Operation = "TRIM"
+		entry.Index, entry.End = normalizeRange(args.Start, end, size)
+	})
+	*reply = removed
+	return err
+}
@@ -0,0 +1,199 @@
+package remotelist
+
+import (
+	"fmt"
+	"time"
+)
+
+type SetTTLArgs struct {
+	ListName string
+	TTL      time.Duration // <= 0 remove o TTL: a lista deixa de expirar
+}
+
+// expiryFromTTL converte um TTL no instante de expiração em Unix ms (0 = não expira)
+func expiryFromTTL(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return time.Now().Add(ttl).UnixMilli()
+}
+
+// trackExpiry registra a expiração de um elemento adicionado à lista
+func (list *listState) trackExpiry(expiresAt int64) {
+	if expiresAt != 0 && (list.nextExpiry == 0 || expiresAt < list.nextExpiry) {
+		list.nextExpiry = expiresAt
+	}
+}
+
+// expired informa se a lista, ou algum elemento dela, pode ter vencido em now
+func (list *listState) expired(now int64) bool {
+	if list.expiresAt != 0 && list.expiresAt <= now {
+		return true
+	}
+	return list.nextExpiry != 0 && list.nextExpiry <= now
+}
+
+// expiringLists retorna as listas citadas pelo nome, ou todas se nenhum for citado
+func (l *RemoteList) expiringLists(names []string) []*listState {
+	var lists []*listState
+	if len(names) == 0 {
+		for _, list := range l.lists {
+			lists = append(lists, list)
+		}
+		return lists
+	}
+	for _, name := range names {
+		// Na cópia de uma transação só as listas envolvidas existem em l.lists
+		if list, exists := l.lists[l.nameToUUID[name]]; exists {
+			lists = append(lists, list)
+		}
+	}
+	return lists
+}
+
+// hasExpiredLocked informa se alguma das listas tem dados vencidos ainda não
+// removidos. Deve ser chamado com o lock
+func (l *RemoteList) hasExpiredLocked(names ...string) bool {
+	now := time.Now().UnixMilli()
+	for _, list := range l.expiringLists(names) {
+		if list.expired(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// expireLocked apaga as listas vencidas e remove os elementos vencidos das listas
+// citadas (de todas, se nenhuma for citada). As expirações são gravadas no WAL como
+// EXPIRE_LIST e EXPIRE, então o recovery não traz de volta dados expirados.
+// Deve ser chamado com o write lock
+func (l *RemoteList) expireLocked(names ...string) {
+	now := time.Now().UnixMilli()
+	for _, list := range l.expiringLists(names) {
+		if !list.expired(now) {
+			continue
+		}
+		err := l.expireListLocked(list, now)
+		if err != nil {
+			// A operação seguinte recebe o mesmo erro do WAL; a varredura tenta de novo
+			fmt.Printf("Erro ao expirar lista '%s': %v\n", list.name, err)
+		}
+	}
+}
+
+func (l *RemoteList) expireListLocked(list *listState, now int64) error {
+	name := list.name
+	if list.expiresAt != 0 && list.expiresAt <= now {
+		entry := l.newListEntry("EXPIRE_LIST", name)
+		_, err := l.commit(&entry)
+		if err != nil {
+			return err
+		}
+		l.logf("Lista '%s' expirada\n", name)
+		return nil
+	}
+
+	// nextExpiry pode estar adiantada (o elemento que vencia primeiro já foi
+	// removido): recalcula antes de gravar uma expiração que não removeria nada
+	list.nextExpiry = list.items.NextExpiry()
+	if !list.expired(now) {
+		return nil
+	}
+
+	size := list.items.Len()
+	entry := l.newListEntry("EXPIRE", name)
+	entry.ExpiresAt = now
+	_, err := l.commit(&entry)
+	if err != nil {
+		return err
+	}
+	l.logf("Lista '%s': %v (%d elementos expirados)\n", name, list.items, size-list.items.Len())
+	return nil
+}
+
+// lockLists adquire o write lock e aplica as expirações vencidas das listas citadas,
+// para que a operação não veja dados expirados
+func (l *RemoteList) lockLists(names ...string) {
+	l.mu.Lock()
+	l.expireLocked(names...)
+}
+
+// rlockLists adquire o read lock para ler as listas citadas (todas, se nenhuma for
+// citada). Na maioria das leituras nada venceu e só o read lock é usado; se alguma
+// lista tiver dados vencidos, a expiração é aplicada antes, com o write lock
+func (l *RemoteList) rlockLists(names ...string) {
+	l.mu.RLock()
+	if !l.hasExpiredLocked(names...) {
+		return
+	}
+	l.mu.RUnlock()
+
+	l.lockLists(names...)
+	l.mu.Unlock()
+	l.mu.RLock()
+}
+
+// setTTLLocked define o instante em que a lista expira
+func (l *RemoteList) setTTLLocked(name string, ttl time.Duration) (uint64, error) {
+	_, err := l.getList(name)
+	if err != nil {
+		return 0, err
+	}
+
+	entry := l.newListEntry("SET_TTL", name)
+	entry.ExpiresAt = expiryFromTTL(ttl)
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return 0, err
+	}
+	if entry.ExpiresAt == 0 {
+		l.logf("Lista '%s' sem TTL\n", name)
+	} else {
+		l.logf("Lista '%s' expira em %v\n", name, time.UnixMilli(entry.ExpiresAt).Format(time.RFC3339))
+	}
+	return lsn, nil
+}
+
+// SetTTL faz a lista inteira expirar (ser apagada) depois de TTL, contado a partir
+// desta chamada. Chamar de novo renova o prazo; TTL <= 0 remove a expiração
+func (l *RemoteList) SetTTL(args SetTTLArgs, reply *bool) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.setTTLLocked(args.ListName, args.TTL)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	*reply = true
+	return nil
+}
+
+// startExpiryRoutine inicia a varredura periódica de TTL. Leituras e escritas já
+// expiram os dados vencidos das listas que acessam; a varredura libera as listas
+// que ninguém mais acessa. A verificação é feita com o read lock; o write lock só
+// é pego quando alguma lista tem dados vencidos
+func (l *RemoteList) startExpiryRoutine(interval time.Duration) {
+	l.bgWG.Add(1)
+	go func() {
+		defer l.bgWG.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-l.stopCh:
+				return
+			case <-ticker.C:
+			}
+
+			l.rlockLists()
+			l.mu.RUnlock()
+		}
+	}()
+	fmt.Printf("Expiração automática iniciada (intervalo: %v)\n", interval)
+}
@@ -0,0 +1,36 @@
+package remotelist
+
+import (
+	"testing"
+	"time"
+)
+
+// A varredura apaga as listas vencidas que ninguém acessa
+func TestExpiryRoutineRemovesUnreadLists(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	config.ExpiryInterval = 10 * time.Millisecond
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	var ok bool
+	list.Append(AppendArgs{ListName: "sessao", Value: IntValue(1)}, &ok)
+	list.SetTTL(SetTTLArgs{ListName: "sessao", TTL: 20 * time.Millisecond}, &ok)
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		list.mu.RLock()
+		_, exists := list.nameToUUID["sessao"]
+		list.mu.RUnlock()
+		if !exists {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("a lista vencida não foi removida pela varredura")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
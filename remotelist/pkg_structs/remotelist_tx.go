@@ -0,0 +1,195 @@
+package remotelist
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type MoveArgs struct {
+	Source      string
+	Destination string // criada se não existir; pode ser a própria Source (rotação)
+	FromEnd     bool   // retira do fim de Source (senão, do início)
+	ToEnd       bool   // adiciona ao fim de Destination (senão, ao início)
+}
+
+type MoveReply struct {
+	Value Value
+	Moved bool // false se Destination está cheia com CapDropNewest: nada foi movido
+}
+
+// TransactionArgs usa as mesmas operações do Batch, mas aplicadas tudo ou nada
+type TransactionArgs struct {
+	Ops []BatchOp
+}
+
+// Transaction aplica as operações, em qualquer número de listas, de forma atômica:
+// ou todas são aplicadas, ou nenhuma. Se uma operação falhar, a chamada retorna o
+// erro dela e o estado não muda. As leituras (Get, Size) veem as escritas anteriores
+// da própria transação.
+//
+// A transação é preparada em uma cópia das listas envolvidas e gravada no WAL como
+// um único registro TX com todas as operações. Uma queda no meio da escrita deixa
+// um registro incompleto no fim do WAL, que o recovery descarta: a transação é
+// reaplicada inteira ou ignorada
+func (l *RemoteList) Transaction(args TransactionArgs, reply *BatchReply) error {
+	if len(args.Ops) > maxBatchOps {
+		return fmt.Errorf("transaction too large: %d operations (max %d)", len(args.Ops), maxBatchOps)
+	}
+
+	results := make([]BatchResult, len(args.Ops))
+
+	l.mu.Lock()
+	stage := l.stageTransaction(args.Ops)
+	for i, op := range args.Ops {
+		value, lsn, err := stage.execOpLocked(op)
+		if err != nil {
+			l.mu.Unlock()
+			return fmt.Errorf("transaction aborted at operation %d (%s): %v", i, op.Op, err)
+		}
+		results[i].Value = value
+		results[i].Dropped = dropped(op, lsn)
+	}
+
+	if len(stage.txEntries) == 0 {
+		// Só leituras: nada a gravar
+		l.mu.Unlock()
+		reply.Results = results
+		return nil
+	}
+
+	entry := LogEntry{Operation: "TX", Ops: stage.txEntries}
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		l.mu.Unlock()
+		return err
+	}
+	fmt.Printf("Transação aplicada: %d operações (LSN %d)\n", len(stage.txEntries), lsn)
+	l.mu.Unlock()
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	reply.Results = results
+	return nil
+}
+
+// stageTransaction cria a cópia do estado em que a transação é preparada. Só as
+// listas citadas nas operações são copiadas; os nomes são todos, para as
+// verificações de RenameList. Deve ser chamado com o write lock
+func (l *RemoteList) stageTransaction(ops []BatchOp) *RemoteList {
+	stage := &RemoteList{
+		nameToUUID: make(map[string]uuid.UUID, len(l.nameToUUID)),
+		lists:      make(map[uuid.UUID]*listState),
+		staging:    true,
+	}
+	for name, listUUID := range l.nameToUUID {
+		stage.nameToUUID[name] = listUUID
+	}
+
+	for _, op := range ops {
+		for _, name := range []string{op.ListName, op.NewName} {
+			listUUID, exists := l.nameToUUID[name]
+			if !exists {
+				continue
+			}
+			if _, copied := stage.lists[listUUID]; copied {
+				continue
+			}
+			list := l.lists[listUUID]
+			stage.lists[listUUID] = &listState{
+				uuid:       list.uuid,
+				name:       list.name,
+				createdAt:  list.createdAt,
+				version:    list.version,
+				items:      list.items.clone(),
+				expiresAt:  list.expiresAt,
+				nextExpiry: list.nextExpiry,
+				maxLength:  list.maxLength,
+				capPolicy:  list.capPolicy,
+			}
+		}
+	}
+	return stage
+}
+
+// moveLocked retira um elemento de src e o adiciona a dst em um único registro TX.
+// Retorna lsn 0, sem erro, se dst está cheia com CapDropNewest. Deve ser chamado
+// com o write lock
+func (l *RemoteList) moveLocked(src, dst string, fromEnd, toEnd bool) (Value, uint64, error) {
+	list, err := l.getList(src)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	if list.items.Len() == 0 {
+		return Value{}, 0, errors.New("empty list")
+	}
+
+	// Destino cheio segue a política dele, como Append e PushFront, mas com
+	// CapDropNewest o elemento fica na origem em vez de ser descartado
+	if target, err := l.getList(dst); err == nil && src != dst && target.full() {
+		switch target.capPolicy {
+		case CapDropNewest:
+			l.logf("Lista '%s' cheia (%d elementos): nada movido de '%s'\n", dst, target.maxLength, src)
+			return Value{}, 0, nil
+		case CapReject:
+			return Value{}, 0, ErrListFull
+		}
+	}
+
+	popOperation, index := "POP_FRONT", 0
+	if fromEnd {
+		popOperation, index = "REMOVE", list.items.Len()-1
+	}
+	pushOperation := "PUSH_FRONT"
+	if toEnd {
+		pushOperation = "APPEND"
+	}
+
+	value := list.items.At(index)
+	pop := l.newListEntry(popOperation, src)
+	pop.Value = value
+	push := l.newListEntry(pushOperation, dst)
+	push.Value = value
+	push.ExpiresAt = list.items.ExpiryAt(index) // o elemento mantém a expiração
+
+	entry := LogEntry{Operation: "TX", Ops: []LogEntry{pop, push}}
+	lsn, err := l.commit(&entry)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	l.logf("Valor %v movido de '%s' para '%s'\n", value, src, dst)
+	return value, lsn, nil
+}
+
+// Move retira um elemento de Source e o adiciona a Destination atomicamente,
+// retornando o valor movido. É o padrão de fila confiável: o worker move a tarefa
+// de "fila" para "processando_<worker>" e, se cair, a tarefa continua lá.
+// Se Destination está cheia, vale a política dela: CapDropOldest aceita o elemento,
+// CapDropNewest responde Moved = false sem alterar as listas e CapReject retorna
+// ErrListFull
+func (l *RemoteList) Move(args MoveArgs, reply *MoveReply) error {
+	*reply = MoveReply{}
+
+	l.lockLists(args.Source, args.Destination)
+	value, lsn, err := l.moveLocked(args.Source, args.Destination, args.FromEnd, args.ToEnd)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if lsn == 0 {
+		return nil // destino cheio (CapDropNewest): nada movido
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	reply.Value = value
+	reply.Moved = true
+	return nil
+}
@@ -0,0 +1,45 @@
+package remotelist
+
+import "testing"
+
+func TestMoveIntoFullList(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	policies := []CapPolicy{CapDropOldest, CapDropNewest, CapReject}
+	for _, policy := range policies {
+		src, dst := "origem_"+string(policy), "destino_"+string(policy)
+		var ok bool
+		list.Append(AppendArgs{ListName: src, Value: IntValue(1)}, &ok)
+		list.SetCap(SetCapArgs{ListName: dst, MaxLength: 1, Policy: policy}, &ok)
+		list.Append(AppendArgs{ListName: dst, Value: IntValue(2)}, &ok)
+
+		var reply MoveReply
+		err := list.Move(MoveArgs{Source: src, Destination: dst, ToEnd: true}, &reply)
+
+		var srcSize int
+		list.Size(SizeArgs{ListName: src}, &srcSize)
+		var dstValue Value
+		list.Get(GetArgs{ListName: dst, Index: 0}, &dstValue)
+
+		switch policy {
+		case CapDropOldest:
+			if err != nil || !reply.Moved || reply.Value != IntValue(1) || srcSize != 0 || dstValue != IntValue(1) {
+				t.Fatalf("%s: err=%v reply=%+v origem=%d destino=%v", policy, err, reply, srcSize, dstValue)
+			}
+		case CapDropNewest:
+			if err != nil || reply.Moved || srcSize != 1 || dstValue != IntValue(2) {
+				t.Fatalf("%s: err=%v reply=%+v origem=%d destino=%v", policy, err, reply, srcSize, dstValue)
+			}
+		case CapReject:
+			if err != ErrListFull || reply.Moved || srcSize != 1 || dstValue != IntValue(2) {
+				t.Fatalf("%s: err=%v reply=%+v origem=%d destino=%v", policy, err, reply, srcSize, dstValue)
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package remotelist
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// ValueKind é o tipo de um elemento. Cada elemento carrega o próprio tipo, então
+// uma lista pode misturar tipos
+type ValueKind uint8
+
+const (
+	KindInt ValueKind = iota
+	KindFloat
+	KindString
+	KindBytes
+	KindJSON
+)
+
+func (k ValueKind) String() string {
+	switch k {
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindString:
+		return "string"
+	case KindBytes:
+		return "bytes"
+	case KindJSON:
+		return "json"
+	}
+	return fmt.Sprintf("kind(%d)", uint8(k))
+}
+
+// Value é um elemento de lista. O valor zero é o inteiro 0. Values são comparáveis
+// com ==; documentos JSON são guardados compactados, então documentos iguais a
+// menos de espaços são iguais
+type Value struct {
+	kind ValueKind
+	num  uint64 // int (complemento de dois) ou bits do float64
+	data string // string, bytes ou documento JSON
+}
+
+func IntValue(v int) Value {
+	return Value{kind: KindInt, num: uint64(v)}
+}
+
+func FloatValue(f float64) Value {
+	return Value{kind: KindFloat, num: math.Float64bits(f)}
+}
+
+func StringValue(s string) Value {
+	return Value{kind: KindString, data: s}
+}
+
+func BytesValue(b []byte) Value {
+	return Value{kind: KindBytes, data: string(b)}
+}
+
+// JSONValue valida e compacta um documento JSON
+func JSONValue(doc []byte) (Value, error) {
+	var compact bytes.Buffer
+	err := json.Compact(&compact, doc)
+	if err != nil {
+		return Value{}, fmt.Errorf("invalid JSON value: %v", err)
+	}
+	return Value{kind: KindJSON, data: compact.String()}, nil
+}
+
+func (v Value) Kind() ValueKind {
+	return v.kind
+}
+
+func (v Value) Int() (int, bool) {
+	return int(v.num), v.kind == KindInt
+}
+
+func (v Value) Float() (float64, bool) {
+	return math.Float64frombits(v.num), v.kind == KindFloat
+}
+
+// Str retorna o texto de um valor KindString
+func (v Value) Str() (string, bool) {
+	return v.data, v.kind == KindString
+}
+
+func (v Value) Bytes() ([]byte, bool) {
+	return []byte(v.data), v.kind == KindBytes
+}
+
+func (v Value) JSON() (json.RawMessage, bool) {
+	return json.RawMessage(v.data), v.kind == KindJSON
+}
+
+// asInt retorna o inteiro ou um erro indicando o tipo encontrado
+func (v Value) asInt() (int, error) {
+	if v.kind != KindInt {
+		return 0, fmt.Errorf("value is not an int (%s)", v.kind)
+	}
+	return int(v.num), nil
+}
+
+// asFloat retorna um valor numérico (int ou float) como float64
+func (v Value) asFloat() (float64, error) {
+	switch v.kind {
+	case KindInt:
+		return float64(int(v.num)), nil
+	case KindFloat:
+		return math.Float64frombits(v.num), nil
+	}
+	return 0, fmt.Errorf("value is not numeric (%s)", v.kind)
+}
+
+// String formata o valor para os logs
+func (v Value) String() string {
+	switch v.kind {
+	case KindInt:
+		return strconv.Itoa(int(v.num))
+	case KindFloat:
+		return strconv.FormatFloat(math.Float64frombits(v.num), 'g', -1, 64)
+	case KindString:
+		return strconv.Quote(v.data)
+	case KindBytes:
+		return fmt.Sprintf("bytes(%d)", len(v.data))
+	case KindJSON:
+		return v.data
+	}
+	return v.kind.String()
+}
+
+// Formato JSON (WAL e snapshots): inteiros são números, como no formato antigo, o
+// que mantém legíveis os arquivos gravados antes dos outros tipos. Os demais tipos
+// são objetos com uma chave: {"float": 1.5}, {"string": "abc"},
+// {"bytes": "<base64>"} e {"json": <documento>}
+type valueJSON struct {
+	Float  json.RawMessage `json:"float,omitempty"`
+	String *string         `json:"string,omitempty"`
+	Bytes  *string         `json:"bytes,omitempty"`
+	JSON   json.RawMessage `json:"json,omitempty"`
+}
+
+func (v Value) MarshalJSON() ([]byte, error) {
+	switch v.kind {
+	case KindInt:
+		return []byte(strconv.Itoa(int(v.num))), nil
+	case KindFloat:
+		f := math.Float64frombits(v.num)
+		number := strconv.FormatFloat(f, 'g', -1, 64)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			number = strconv.Quote(number) // JSON não tem NaN nem infinito
+		}
+		return json.Marshal(valueJSON{Float: json.RawMessage(number)})
+	case KindString:
+		return json.Marshal(valueJSON{String: &v.data})
+	case KindBytes:
+		encoded := base64.StdEncoding.EncodeToString([]byte(v.data))
+		return json.Marshal(valueJSON{Bytes: &encoded})
+	case KindJSON:
+		return json.Marshal(valueJSON{JSON: json.RawMessage(v.data)})
+	}
+	return nil, fmt.Errorf("tipo de valor desconhecido: %d", v.kind)
+}
+
+func (v *Value) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		n, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return fmt.Errorf("valor inteiro inválido: %s", data)
+		}
+		*v = IntValue(int(n))
+		return nil
+	}
+
+	var tagged valueJSON
+	err := json.Unmarshal(data, &tagged)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case tagged.Float != nil:
+		text := string(tagged.Float)
+		if unquoted, err := strconv.Unquote(text); err == nil {
+			text = unquoted
+		}
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return fmt.Errorf("valor float inválido: %s", tagged.Float)
+		}
+		*v = FloatValue(f)
+	case tagged.String != nil:
+		*v = StringValue(*tagged.String)
+	case tagged.Bytes != nil:
+		decoded, err := base64.StdEncoding.DecodeString(*tagged.Bytes)
+		if err != nil {
+			return fmt.Errorf("valor bytes inválido: %v", err)
+		}
+		*v = BytesValue(decoded)
+	case tagged.JSON != nil:
+		*v, err = JSONValue(tagged.JSON)
+		return err
+	default:
+		return fmt.Errorf("valor inválido: %s", data)
+	}
+	return nil
+}
+
+// GobEncode serializa o valor para o net/rpc: tipo | número (uint64) | dados
+func (v Value) GobEncode() ([]byte, error) {
+	buf := make([]byte, 9+len(v.data))
+	buf[0] = byte(v.kind)
+	binary.LittleEndian.PutUint64(buf[1:], v.num)
+	copy(buf[9:], v.data)
+	return buf, nil
+}
+
+func (v *Value) GobDecode(data []byte) error {
+	if len(data) < 9 {
+		return errors.New("valor gob inválido")
+	}
+	kind := ValueKind(data[0])
+	num, payload := binary.LittleEndian.Uint64(data[1:]), data[9:]
+
+	// Reconstrói pelos construtores: os campos que o tipo não usa ficam zerados
+	// (mantendo == correto) e documentos JSON passam pela mesma validação
+	var err error
+	switch kind {
+	case KindInt:
+		*v = IntValue(int(num))
+	case KindFloat:
+		*v = FloatValue(math.Float64frombits(num))
+	case KindString:
+		*v = StringValue(string(payload))
+	case KindBytes:
+		*v = BytesValue(payload)
+	case KindJSON:
+		*v, err = JSONValue(payload)
+	default:
+		err = fmt.Errorf("tipo de valor desconhecido: %d", kind)
+	}
+	return err
+}
@@ -0,0 +1,164 @@
+package remotelist
+
+import "errors"
+
+// ErrVersionConflict é retornado pelas escritas condicionais quando a lista foi
+// alterada depois da leitura. Via RPC o cliente recebe a mesma mensagem de erro
+var ErrVersionConflict = errors.New("version conflict")
+
+type AppendIfVersionArgs struct {
+	ListName        string
+	Value           Value
+	ExpectedVersion uint64 // 0: a lista não pode existir (cria a lista)
+}
+
+type RemoveIfVersionArgs struct {
+	ListName        string
+	ExpectedVersion uint64
+}
+
+type SetIfVersionArgs struct {
+	ListName        string
+	Index           int
+	Value           Value
+	ExpectedVersion uint64
+}
+
+// VersionedValue é um valor acompanhado da versão da lista: a da leitura ou, nas
+// escritas condicionais, a nova versão depois da escrita
+type VersionedValue struct {
+	Value   Value
+	Version uint64
+}
+
+// versionLocked retorna a versão da lista: o LSN da última operação que a alterou,
+// preservado nos snapshots, ou 0 se ela não existir. Deve ser chamado com o lock
+func (l *RemoteList) versionLocked(name string) uint64 {
+	listUUID, exists := l.nameToUUID[name]
+	if !exists {
+		return 0
+	}
+	return l.lists[listUUID].version
+}
+
+// checkVersion retorna ErrVersionConflict se a versão da lista não for a esperada
+func (l *RemoteList) checkVersion(name string, expected uint64) error {
+	if l.versionLocked(name) != expected {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// GetWithVersion é o Get que também retorna a versão da lista
+func (l *RemoteList) GetWithVersion(args GetArgs, reply *VersionedValue) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	value, err := l.getLocked(args.ListName, args.Index)
+	if err != nil {
+		return err
+	}
+	reply.Value = value
+	reply.Version = l.versionLocked(args.ListName)
+	return nil
+}
+
+// SizeWithVersion é o Size que também retorna a versão da lista (0 se não existir)
+func (l *RemoteList) SizeWithVersion(args SizeArgs, reply *VersionedValue) error {
+	l.rlockLists(args.ListName)
+	defer l.mu.RUnlock()
+
+	reply.Value = IntValue(l.sizeLocked(args.ListName))
+	reply.Version = l.versionLocked(args.ListName)
+	return nil
+}
+
+func (l *RemoteList) appendIfVersionLocked(name string, value Value, expected uint64) (uint64, error) {
+	err := l.checkVersion(name, expected)
+	if err != nil {
+		return 0, err
+	}
+	return l.appendLocked(name, value, 0)
+}
+
+func (l *RemoteList) removeIfVersionLocked(name string, expected uint64) (Value, uint64, error) {
+	err := l.checkVersion(name, expected)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	return l.removeLocked(name, false)
+}
+
+func (l *RemoteList) setIfVersionLocked(name string, index int, value Value, expected uint64) (Value, uint64, error) {
+	err := l.checkVersion(name, expected)
+	if err != nil {
+		return Value{}, 0, err
+	}
+	return l.setLocked(name, index, value)
+}
+
+// AppendIfVersion faz o Append só se a lista ainda estiver na versão esperada
+func (l *RemoteList) AppendIfVersion(args AppendIfVersionArgs, reply *VersionedValue) error {
+	l.lockLists(args.ListName)
+	lsn, err := l.appendIfVersionLocked(args.ListName, args.Value, args.ExpectedVersion)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if lsn == 0 {
+		// Lista cheia (CapDropNewest): valor descartado, a lista não mudou
+		reply.Value = Value{}
+		reply.Version = args.ExpectedVersion
+		return nil
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	reply.Value = Value{}
+	reply.Version = lsn
+	return nil
+}
+
+// RemoveIfVersion faz o Remove só se a lista ainda estiver na versão esperada,
+// retornando o valor removido
+func (l *RemoteList) RemoveIfVersion(args RemoveIfVersionArgs, reply *VersionedValue) error {
+	l.lockLists(args.ListName)
+	removedValue, lsn, err := l.removeIfVersionLocked(args.ListName, args.ExpectedVersion)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	reply.Value = removedValue
+	reply.Version = lsn
+	return nil
+}
+
+// SetIfVersion faz o Set só se a lista ainda estiver na versão esperada,
+// retornando o valor anterior
+func (l *RemoteList) SetIfVersion(args SetIfVersionArgs, reply *VersionedValue) error {
+	l.lockLists(args.ListName)
+	oldValue, lsn, err := l.setIfVersionLocked(args.ListName, args.Index, args.Value, args.ExpectedVersion)
+	l.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	err = l.waitDurable(lsn)
+	if err != nil {
+		return err
+	}
+
+	reply.Value = oldValue
+	reply.Version = lsn
+	return nil
+}
@@ -0,0 +1,528 @@
+package remotelist
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// legacyWALPath retorna o WAL de versões anteriores (arquivo único, antes da rotação por segmentos)
+func legacyWALPath(dir string) string {
+	return filepath.Join(dir, "wal.log")
+}
+
+// Limites de um segmento do WAL; ao atingir qualquer um deles o segmento é fechado
+// e as próximas escritas vão para um novo arquivo
+const (
+	walSegmentMaxBytes   = 4 * 1024 * 1024
+	walSegmentMaxEntries = 10000
+)
+
+// Espera padrão da goroutine de commit para agrupar escritas concorrentes em um fsync
+const defaultWALMaxBatchDelay = 2 * time.Millisecond
+
+// DurabilityMode define quando as escritas do WAL são sincronizadas com o disco
+type DurabilityMode string
+
+const (
+	DurabilityAlways   DurabilityMode = "always"   // fsync antes de confirmar cada RPC (group commit)
+	DurabilityInterval DurabilityMode = "interval" // fsync periódico em background
+	DurabilityNone     DurabilityMode = "none"     // sem fsync, fica a cargo do sistema operacional
+)
+
+// Intervalo padrão de fsync no modo DurabilityInterval
+const defaultWALSyncInterval = 100 * time.Millisecond
+
+// ParseDurabilityMode valida o nome de um modo de durabilidade
+func ParseDurabilityMode(value string) (DurabilityMode, error) {
+	switch mode := DurabilityMode(strings.ToLower(value)); mode {
+	case DurabilityAlways, DurabilityInterval, DurabilityNone:
+		return mode, nil
+	}
+	return "", fmt.Errorf("modo de durabilidade inválido: %q (use always, interval ou none)", value)
+}
+
+// walSegment é um arquivo do WAL; todas as entradas dele têm LSN >= firstLSN
+type walSegment struct {
+	path     string
+	firstLSN uint64
+}
+
+// walWriter faz group commit do WAL: as entradas são escritas em um buffer na
+// ordem de LSN e uma goroutine de commit faz um único write+fsync para todas as
+// entradas acumuladas. Cada RPC só é confirmado depois que o LSN dela está durável
+type walWriter struct {
+	mu   sync.Mutex
+	cond *sync.Cond // sinaliza avanço de durableLSN, erro ou fim de um fsync
+
+	dir            string
+	file           *os.File
+	buf            *bufio.Writer
+	segmentBytes   int64 // tamanho do segmento atual
+	segmentEntries int   // entradas escritas no segmento atual
+
+	writtenLSN uint64 // último LSN escrito no buffer
+	durableLSN uint64 // último LSN com fsync concluído
+	syncing    bool   // goroutine de commit está em fsync fora do lock
+	overlapped bool   // chegaram entradas durante o último fsync do commitLoop
+	waiters    int    // RPCs bloqueados em waitDurable
+	err        error  // falha de escrita/fsync: o WAL deixa de aceitar escritas
+
+	durability    DurabilityMode
+	maxBatchDelay time.Duration // espera máxima para agrupar entradas em um fsync
+	syncInterval  time.Duration // período de fsync no modo DurabilityInterval
+	commitCh      chan struct{}
+	done          chan struct{} // fechado por close para encerrar as goroutines
+	loops         sync.WaitGroup
+}
+
+// errWALClosed é retornado por escritas depois que o WAL foi fechado
+var errWALClosed = errors.New("WAL fechado: servidor em encerramento")
+
+// newWALWriter abre o segmento que começa em nextLSN e inicia a goroutine de
+// sincronização do modo de durabilidade escolhido
+func newWALWriter(config Config, nextLSN uint64) (*walWriter, error) {
+	w := &walWriter{
+		dir:           config.DataDir,
+		writtenLSN:    nextLSN - 1,
+		durableLSN:    nextLSN - 1,
+		durability:    config.Durability,
+		maxBatchDelay: config.WALBatchDelay,
+		syncInterval:  config.WALSyncInterval,
+		commitCh:      make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+	w.cond = sync.NewCond(&w.mu)
+
+	err := w.openSegment(nextLSN)
+	if err != nil {
+		return nil, err
+	}
+
+	switch w.durability {
+	case DurabilityAlways:
+		w.loops.Add(1)
+		go w.commitLoop()
+	case DurabilityInterval:
+		w.loops.Add(1)
+		go w.syncLoop()
+	}
+	return w, nil
+}
+
+// openSegment abre (ou cria) o segmento cujo primeiro LSN é firstLSN.
+// Deve ser chamado com w.mu
+func (w *walWriter) openSegment(firstLSN uint64) error {
+	file, err := os.OpenFile(walSegmentPath(w.dir, firstLSN), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if info.Size() > 0 && info.Size() <= walHeaderSize {
+		// Segmento sem registros (o recovery já aplicou tudo antes de firstLSN): o
+		// cabeçalho é regravado, pois pode ser de uma versão anterior do formato
+		err = file.Truncate(0)
+		if err != nil {
+			file.Close()
+			return err
+		}
+		info, err = file.Stat()
+		if err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	w.file = file
+	w.buf = bufio.NewWriter(file)
+	w.segmentBytes = info.Size()
+	w.segmentEntries = 0
+
+	if info.Size() == 0 {
+		// Segmento novo: o cabeçalho vai para o disco junto com o primeiro lote
+		n, err := w.buf.Write(walHeader())
+		if err != nil {
+			file.Close()
+			return err
+		}
+		w.segmentBytes += int64(n)
+	}
+	return nil
+}
+
+// append escreve a entrada no buffer do WAL sem esperar o fsync.
+// Deve ser chamado com o write lock da RemoteList, que garante a ordem de LSN
+func (w *walWriter) append(entry LogEntry) error {
+	record, err := encodeWALRecord(entry)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	n, err := w.buf.Write(record)
+	if err != nil {
+		w.err = err
+		return err
+	}
+
+	w.writtenLSN = entry.LSN
+	w.segmentBytes += int64(n)
+	w.segmentEntries++
+
+	if w.durability != DurabilityAlways {
+		// Sem group commit a entrada vai direto para o sistema operacional:
+		// sobrevive a uma queda do processo, mas não do sistema sem fsync
+		err = w.buf.Flush()
+		if err != nil {
+			w.err = err
+			return err
+		}
+	}
+
+	if w.segmentBytes >= walSegmentMaxBytes || w.segmentEntries >= walSegmentMaxEntries {
+		// Falha na rotação só adia a troca de segmento
+		err = w.rotateLocked(entry.LSN + 1)
+		if err != nil {
+			fmt.Printf("Aviso: Erro ao rotacionar WAL: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// waitDurable acorda a goroutine de commit e bloqueia até que lsn esteja em disco.
+// Não deve ser chamado com o lock da RemoteList: enquanto espera, outras escritas
+// entram no mesmo lote
+func (w *walWriter) waitDurable(lsn uint64) error {
+	if w.durability != DurabilityAlways {
+		// interval/none confirmam sem esperar o fsync
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return w.err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.waiters++
+	select {
+	case w.commitCh <- struct{}{}:
+	default: // commit já pendente, este LSN entra nele ou no próximo
+	}
+
+	for w.durableLSN < lsn && w.err == nil {
+		w.cond.Wait()
+	}
+	w.waiters--
+	if w.durableLSN >= lsn {
+		return nil
+	}
+	return w.err
+}
+
+// commitLoop agrupa as entradas pendentes e faz um write+fsync por lote. Só espera
+// maxBatchDelay antes do fsync quando há escritas concorrentes (outros RPCs já
+// esperando ou entradas que chegaram durante o fsync anterior); um escritor
+// sozinho tem o fsync imediato
+func (w *walWriter) commitLoop() {
+	defer w.loops.Done()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-w.commitCh:
+		}
+
+		w.mu.Lock()
+		concurrent := w.waiters > 1 || w.overlapped
+		w.mu.Unlock()
+
+		if concurrent && w.maxBatchDelay > 0 {
+			// Dá tempo para outras escritas concorrentes entrarem no lote
+			time.Sleep(w.maxBatchDelay)
+		}
+
+		w.mu.Lock()
+		if w.err != nil || w.durableLSN >= w.writtenLSN {
+			w.mu.Unlock()
+			continue
+		}
+
+		err := w.buf.Flush()
+		if err != nil {
+			w.err = err
+			w.cond.Broadcast()
+			w.mu.Unlock()
+			continue
+		}
+		target := w.writtenLSN
+		file := w.file
+		w.syncing = true
+		w.mu.Unlock()
+
+		// fsync fora do lock: novas entradas continuam indo para o buffer
+		err = file.Sync()
+
+		w.mu.Lock()
+		w.syncing = false
+		w.overlapped = w.writtenLSN > target
+		if err != nil {
+			w.err = err
+		} else if target > w.durableLSN {
+			w.durableLSN = target
+		}
+		w.cond.Broadcast()
+		w.mu.Unlock()
+	}
+}
+
+// syncLoop faz fsync periódico do segmento atual no modo DurabilityInterval
+func (w *walWriter) syncLoop() {
+	defer w.loops.Done()
+
+	ticker := time.NewTicker(w.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+
+		w.mu.Lock()
+		if w.err == nil && w.durableLSN < w.writtenLSN {
+			err := w.syncLocked()
+			if err != nil {
+				fmt.Printf("Erro no fsync periódico do WAL: %v\n", err)
+			}
+		}
+		w.mu.Unlock()
+	}
+}
+
+// syncLocked grava o buffer e faz fsync do segmento atual. Na rotação o fsync é feito
+// em qualquer modo: só o último segmento pode ter registro incompleto após uma queda.
+// Deve ser chamado com w.mu
+func (w *walWriter) syncLocked() error {
+	for w.syncing {
+		w.cond.Wait()
+	}
+	if w.err != nil {
+		return w.err
+	}
+
+	err := w.buf.Flush()
+	if err == nil {
+		err = w.file.Sync()
+	}
+	if err != nil {
+		w.err = err
+		w.cond.Broadcast()
+		return err
+	}
+
+	w.durableLSN = w.writtenLSN
+	w.cond.Broadcast()
+	return nil
+}
+
+// rotateLocked fecha o segmento atual (com fsync) e passa a escrever em um novo
+// que começa em nextLSN. Deve ser chamado com w.mu
+func (w *walWriter) rotateLocked(nextLSN uint64) error {
+	err := w.syncLocked()
+	if err != nil {
+		return err
+	}
+
+	oldFile := w.file
+	err = w.openSegment(nextLSN)
+	if err != nil {
+		return err // continua escrevendo no segmento atual
+	}
+	oldFile.Close()
+	return nil
+}
+
+// close encerra as goroutines do WAL, grava e sincroniza o que estiver pendente e
+// fecha o segmento atual. Escritas posteriores retornam errWALClosed
+func (w *walWriter) close() error {
+	select {
+	case <-w.done:
+		return nil // já fechado
+	default:
+	}
+	close(w.done)
+	w.loops.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.syncLocked()
+	closeErr := w.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	w.err = errWALClosed
+	w.cond.Broadcast()
+	return err
+}
+
+// rotate é a versão de rotateLocked que adquire w.mu
+func (w *walWriter) rotate(nextLSN uint64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotateLocked(nextLSN)
+}
+
+// writeWAL atribui o próximo LSN à entrada e a escreve no Write-Ahead Log.
+// Deve ser chamado com o write lock; o retorno é o LSN a ser passado para
+// waitDurable depois que o lock for liberado
+func (l *RemoteList) writeWAL(entry *LogEntry) (uint64, error) {
+	entry.LSN = l.currentLSN + 1
+	entry.Timestamp = time.Now().Unix()
+
+	err := l.wal.append(*entry)
+	if err != nil {
+		return 0, err
+	}
+
+	l.currentLSN = entry.LSN
+	return entry.LSN, nil
+}
+
+// walSegmentPath retorna o caminho do segmento cujo primeiro LSN é firstLSN
+func walSegmentPath(dir string, firstLSN uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("wal_%d.log", firstLSN))
+}
+
+// listWALSegments retorna os segmentos do WAL em ordem crescente de LSN
+func listWALSegments(dir string) ([]walSegment, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []walSegment
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasPrefix(name, "wal_") || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		lsnStr := strings.TrimSuffix(strings.TrimPrefix(name, "wal_"), ".log")
+		firstLSN, err := strconv.ParseUint(lsnStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, walSegment{path: filepath.Join(dir, name), firstLSN: firstLSN})
+	}
+
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].firstLSN < segments[j].firstLSN
+	})
+	return segments, nil
+}
+
+// removeOldWALSegments apaga apenas segmentos inteiros cujas entradas estão todas
+// cobertas pelo snapshot de LSN coveredLSN. O último LSN de um segmento é o primeiro
+// LSN do segmento seguinte menos um; o segmento ativo (o último) nunca é apagado
+func (l *RemoteList) removeOldWALSegments(coveredLSN uint64) error {
+	segments, err := listWALSegments(l.config.DataDir)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(segments); i++ {
+		lastLSN := segments[i+1].firstLSN - 1
+		if lastLSN > coveredLSN {
+			break
+		}
+		err := os.Remove(segments[i].path)
+		if err != nil {
+			fmt.Printf("Aviso: Erro ao remover segmento %s: %v\n", segments[i].path, err)
+		} else {
+			fmt.Printf("Segmento do WAL removido: %s\n", segments[i].path)
+		}
+	}
+
+	// WAL legado (arquivo único): só recebeu escritas antes do primeiro segmento
+	if len(segments) > 0 && segments[0].firstLSN-1 <= coveredLSN {
+		legacyPath := legacyWALPath(l.config.DataDir)
+		err = os.Remove(legacyPath)
+		if err == nil {
+			fmt.Printf("WAL legado removido: %s\n", legacyPath)
+		} else if !os.IsNotExist(err) {
+			fmt.Printf("Aviso: Erro ao remover WAL legado: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// walFilesForReplay retorna os arquivos do WAL na ordem de replay:
+// o arquivo legado (se houver) seguido dos segmentos em ordem de LSN
+func walFilesForReplay(dir string) ([]string, error) {
+	walFiles := make([]string, 0)
+	if _, err := os.Stat(legacyWALPath(dir)); err == nil {
+		walFiles = append(walFiles, legacyWALPath(dir))
+	}
+
+	segments, err := listWALSegments(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, segment := range segments {
+		walFiles = append(walFiles, segment.path)
+	}
+
+	return walFiles, nil
+}
+
+// replayWALFile aplica as entradas de um arquivo do WAL com LSN > snapshotLSN.
+// isLast indica o arquivo mais recente, o único onde um registro incompleto é esperado
+func (l *RemoteList) replayWALFile(walFile string, snapshotLSN uint64, isLast bool) (int, error) {
+	appliedOps := 0
+
+	err := readWALFile(walFile, isLast, func(entry LogEntry) error {
+		// Aplica apenas operações APÓS o snapshot
+		if entry.LSN <= snapshotLSN {
+			return nil
+		}
+
+		// O WAL legado pode ter lacunas de versões antigas; os segmentos não
+		if walFile != legacyWALPath(l.config.DataDir) && entry.LSN != l.currentLSN+1 {
+			return fmt.Errorf("lacuna no WAL: %s contém LSN %d, esperado %d; "+
+				"o servidor não será iniciado para não descartar escritas confirmadas", walFile, entry.LSN, l.currentLSN+1)
+		}
+
+		// Replay da operação
+		l.applyEntry(entry)
+
+		l.currentLSN = entry.LSN
+		appliedOps++
+		return nil
+	})
+
+	return appliedOps, err
+}
@@ -0,0 +1,258 @@
+package remotelist
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// Formato binário dos segmentos do WAL:
+//
+//	cabeçalho do segmento: magic "RLWL" | versão (uint16) | reservado (uint16)
+//	cada registro:         tamanho do payload (uint32) | CRC32-C do tamanho (uint32) | CRC32-C do payload (uint32) | payload
+//
+// O payload é o LogEntry serializado em JSON. Inteiros em little-endian.
+// O checksum do tamanho permite distinguir um registro final incompleto (cabeçalho
+// válido, arquivo termina antes do fim do registro) de um tamanho corrompido no meio
+// do log. Segmentos da versão 1, sem esse checksum, continuam legíveis.
+// Arquivos sem o magic são lidos como o WAL antigo em JSON Lines
+const (
+	walMagic              = "RLWL"
+	walFormatVersion      = 2
+	walHeaderSize         = 8
+	walRecordHeaderSize   = 12
+	walV1RecordHeaderSize = 8
+	walMaxRecordSize      = 16 * 1024 * 1024
+)
+
+var walCRCTable = crc32.MakeTable(crc32.Castagnoli)
+
+// walHeader retorna o cabeçalho gravado no início de cada segmento binário
+func walHeader() []byte {
+	header := make([]byte, walHeaderSize)
+	copy(header, walMagic)
+	binary.LittleEndian.PutUint16(header[4:], walFormatVersion)
+	return header
+}
+
+// encodeWALRecord serializa uma entrada no formato de registro binário
+func encodeWALRecord(entry LogEntry) ([]byte, error) {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > walMaxRecordSize {
+		// O recovery recusa registros maiores, então eles nunca podem ser confirmados
+		return nil, fmt.Errorf("%w: %d bytes (max %d)", ErrRecordTooLarge, len(payload), walMaxRecordSize)
+	}
+
+	record := make([]byte, walRecordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record[0:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:], crc32.Checksum(record[0:4], walCRCTable))
+	binary.LittleEndian.PutUint32(record[8:], crc32.Checksum(payload, walCRCTable))
+	copy(record[walRecordHeaderSize:], payload)
+	return record, nil
+}
+
+// ErrRecordTooLarge é retornado por uma escrita cujo registro no WAL passaria do
+// limite walMaxRecordSize (um valor muito grande ou uma transação com muitas
+// operações); nada é aplicado
+var ErrRecordTooLarge = errors.New("record too large")
+
+// errWALTornRecord indica um último registro incompleto (escrita interrompida por queda)
+var errWALTornRecord = errors.New("registro final incompleto")
+
+// readWALFile lê as entradas de um arquivo do WAL, binário ou JSON legado, chamando
+// apply para cada uma. Em isLast (arquivo que recebia escritas no momento da queda)
+// um registro final incompleto ou com checksum inválido é truncado; em qualquer
+// outra posição, corrupção é erro e o servidor não deve iniciar
+func readWALFile(path string, isLast bool, apply func(LogEntry) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir WAL: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("erro ao abrir WAL: %v", err)
+	}
+
+	if info.Size() == 0 {
+		return nil
+	}
+
+	reader := bufio.NewReader(file)
+	prefix, err := reader.Peek(len(walMagic))
+	if err != nil || !bytes.Equal(prefix, []byte(walMagic)) {
+		if info.Size() < walHeaderSize && isLast && bytes.HasPrefix([]byte(walMagic), prefix) {
+			// Cabeçalho de um segmento recém-criado, interrompido pela queda
+			file.Close()
+			return truncateTornWAL(path, 0, 0)
+		}
+		return readLegacyWAL(path, reader, apply)
+	}
+
+	header := make([]byte, walHeaderSize)
+	_, err = io.ReadFull(reader, header)
+	if err != nil {
+		if isLast {
+			file.Close()
+			return truncateTornWAL(path, 0, 0)
+		}
+		return fmt.Errorf("WAL corrompido em %s: cabeçalho incompleto", path)
+	}
+	version := binary.LittleEndian.Uint16(header[4:])
+	if version != 1 && version != walFormatVersion {
+		return fmt.Errorf("WAL %s usa a versão de formato %d, não suportada (esperado até %d)", path, version, walFormatVersion)
+	}
+
+	offset := int64(walHeaderSize)
+	var lastLSN uint64
+	for {
+		entry, size, err := readWALRecord(reader, info.Size()-offset, version)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			if isLast && errors.Is(err, errWALTornRecord) {
+				file.Close()
+				return truncateTornWAL(path, offset, lastLSN)
+			}
+			return fmt.Errorf("WAL corrompido em %s, offset %d (último LSN válido: %d): %v; "+
+				"o servidor não será iniciado para não descartar escritas confirmadas, "+
+				"restaure o arquivo ou remova-o manualmente aceitando a perda", path, offset, lastLSN, err)
+		}
+
+		if lastLSN != 0 && entry.LSN != lastLSN+1 {
+			return fmt.Errorf("WAL corrompido em %s, offset %d: LSN %d após LSN %d", path, offset, entry.LSN, lastLSN)
+		}
+
+		err = apply(entry)
+		if err != nil {
+			return err
+		}
+		lastLSN = entry.LSN
+		offset += size
+	}
+}
+
+// readWALRecord lê um registro binário; remaining é quantos bytes restam no arquivo.
+// Retorna io.EOF no fim exato do arquivo e errWALTornRecord quando o problema só
+// pode ter sido causado por uma escrita interrompida no fim do arquivo: cabeçalho
+// cortado, cabeçalho válido com o arquivo terminando dentro do registro, último
+// registro com payload inválido ou um final preenchido com zeros (arquivo estendido
+// sem os dados). Um tamanho com checksum inválido é corrupção
+func readWALRecord(reader *bufio.Reader, remaining int64, version uint16) (LogEntry, int64, error) {
+	var entry LogEntry
+
+	headerSize := int64(walRecordHeaderSize)
+	if version == 1 {
+		headerSize = walV1RecordHeaderSize
+	}
+	header := make([]byte, headerSize)
+	n, err := io.ReadFull(reader, header)
+	if err == io.EOF {
+		return entry, 0, io.EOF
+	}
+	if err != nil {
+		return entry, 0, fmt.Errorf("%w: cabeçalho com %d de %d bytes", errWALTornRecord, n, headerSize)
+	}
+
+	length := int64(binary.LittleEndian.Uint32(header[0:]))
+	checksum := binary.LittleEndian.Uint32(header[headerSize-4:])
+	size := headerSize + length
+
+	if version != 1 && crc32.Checksum(header[0:4], walCRCTable) != binary.LittleEndian.Uint32(header[4:]) {
+		if isZeroFilled(io.MultiReader(bytes.NewReader(header), reader)) {
+			return entry, 0, fmt.Errorf("%w: final do arquivo preenchido com zeros", errWALTornRecord)
+		}
+		return entry, 0, errors.New("checksum do tamanho do registro inválido")
+	}
+	if length > walMaxRecordSize {
+		return entry, 0, fmt.Errorf("tamanho de registro inválido: %d bytes", length)
+	}
+	if size > remaining {
+		// Na versão 1 o tamanho não tem checksum: um tamanho corrompido também cai aqui
+		return entry, 0, fmt.Errorf("%w: registro de %d bytes com %d disponíveis", errWALTornRecord, size, remaining)
+	}
+
+	payload := make([]byte, length)
+	_, err = io.ReadFull(reader, payload)
+	if err != nil {
+		return entry, 0, fmt.Errorf("%w: %v", errWALTornRecord, err)
+	}
+
+	if crc32.Checksum(payload, walCRCTable) != checksum {
+		if size == remaining {
+			// Último registro do arquivo: escrita parcial antes da queda
+			return entry, 0, fmt.Errorf("%w: checksum inválido", errWALTornRecord)
+		}
+		return entry, 0, errors.New("checksum inválido")
+	}
+
+	err = json.Unmarshal(payload, &entry)
+	if err != nil {
+		return entry, 0, fmt.Errorf("registro ilegível: %v", err)
+	}
+
+	return entry, size, nil
+}
+
+// isZeroFilled informa se o restante do reader só tem bytes zero
+func isZeroFilled(reader io.Reader) bool {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := reader.Read(buf)
+		for _, b := range buf[:n] {
+			if b != 0 {
+				return false
+			}
+		}
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// truncateTornWAL descarta o registro final incompleto a partir de offset.
+// Um registro incompleto nunca foi confirmado ao cliente, então nada é perdido
+func truncateTornWAL(path string, offset int64, lastLSN uint64) error {
+	err := os.Truncate(path, offset)
+	if err != nil {
+		return fmt.Errorf("erro ao truncar registro incompleto do WAL %s: %v", path, err)
+	}
+	fmt.Printf(" Aviso: registro final incompleto em %s truncado no offset %d (último LSN válido: %d)\n", path, offset, lastLSN)
+	return nil
+}
+
+// readLegacyWAL lê o WAL antigo em JSON Lines. Esse formato não distingue fim do
+// log de corrupção, então a leitura para na primeira linha inválida
+func readLegacyWAL(path string, reader io.Reader, apply func(LogEntry) error) error {
+	decoder := json.NewDecoder(reader)
+	for {
+		var entry LogEntry
+		err := decoder.Decode(&entry)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			fmt.Printf(" Aviso: leitura do WAL legado %s interrompida: %v\n", path, err)
+			return nil
+		}
+
+		err = apply(entry)
+		if err != nil {
+			return err
+		}
+	}
+}
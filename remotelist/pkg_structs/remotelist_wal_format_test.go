@@ -0,0 +1,192 @@
+package remotelist
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestSegment grava um segmento com count entradas APPEND (LSN 1..count) e
+// retorna o offset de início de cada registro
+func writeTestSegment(t *testing.T, path string, count int) []int64 {
+	t.Helper()
+	data := walHeader()
+	var offsets []int64
+	for i := 1; i <= count; i++ {
+		record, err := encodeWALRecord(LogEntry{LSN: uint64(i), Operation: "APPEND", ListName: "l", Value: IntValue(i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		offsets = append(offsets, int64(len(data)))
+		data = append(data, record...)
+	}
+	err := os.WriteFile(path, data, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return offsets
+}
+
+// readTestSegment lê o segmento como o último do WAL e retorna os LSNs aplicados
+func readTestSegment(path string) ([]uint64, error) {
+	var lsns []uint64
+	err := readWALFile(path, true, func(entry LogEntry) error {
+		lsns = append(lsns, entry.LSN)
+		return nil
+	})
+	return lsns, err
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
+func TestReadWALFileRejectsCorruptedLength(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal_1.log")
+	offsets := writeTestSegment(t, path, 10)
+	size := fileSize(t, path)
+
+	data, _ := os.ReadFile(path)
+	data[offsets[1]+1] ^= 0x10 // tamanho do registro 2 passa do fim do arquivo
+	os.WriteFile(path, data, 0644)
+
+	lsns, err := readTestSegment(path)
+	if err == nil || errors.Is(err, errWALTornRecord) {
+		t.Fatalf("esperado erro de corrupção, recebido %v", err)
+	}
+	if len(lsns) != 1 {
+		t.Fatalf("esperado 1 registro aplicado antes da corrupção, recebido %d", len(lsns))
+	}
+	if fileSize(t, path) != size {
+		t.Fatalf("o WAL corrompido não pode ser truncado")
+	}
+}
+
+func TestRecoverRefusesCorruptedLength(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	path := walSegmentPath(config.DataDir, 1)
+	offsets := writeTestSegment(t, path, 10)
+
+	data, _ := os.ReadFile(path)
+	data[offsets[1]+1] ^= 0x10
+	os.WriteFile(path, data, 0644)
+
+	list, err := NewRemoteListWithConfig(config)
+	if err == nil {
+		list.Close()
+		t.Fatal("o servidor não deve iniciar com o WAL corrompido no meio")
+	}
+}
+
+func TestReadWALFileTruncatesTornTail(t *testing.T) {
+	cuts := map[string]func(offsets []int64, size int64) int64{
+		"cabeçalho cortado": func(offsets []int64, size int64) int64 { return offsets[9] + 5 },
+		"payload cortado":   func(offsets []int64, size int64) int64 { return size - 3 },
+	}
+	for name, cut := range cuts {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "wal_1.log")
+			offsets := writeTestSegment(t, path, 10)
+			os.Truncate(path, cut(offsets, fileSize(t, path)))
+
+			lsns, err := readTestSegment(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(lsns) != 9 {
+				t.Fatalf("esperado 9 registros, recebido %d", len(lsns))
+			}
+			if fileSize(t, path) != offsets[9] {
+				t.Fatalf("registro incompleto não foi truncado: tamanho %d, esperado %d", fileSize(t, path), offsets[9])
+			}
+		})
+	}
+}
+
+func TestReadWALFileTruncatesZeroFilledTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wal_1.log")
+	writeTestSegment(t, path, 10)
+	size := fileSize(t, path)
+	os.Truncate(path, size+100) // arquivo estendido sem os dados antes da queda
+
+	lsns, err := readTestSegment(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lsns) != 10 || fileSize(t, path) != size {
+		t.Fatalf("esperado 10 registros e tamanho %d, recebido %d e %d", size, len(lsns), fileSize(t, path))
+	}
+}
+
+func TestReadWALFileVersion1(t *testing.T) {
+	header := walHeader()
+	binary.LittleEndian.PutUint16(header[4:], 1)
+	data := header
+	for i := 1; i <= 3; i++ {
+		payload, _ := json.Marshal(LogEntry{LSN: uint64(i), Operation: "APPEND", ListName: "l", Value: IntValue(i)})
+		record := make([]byte, walV1RecordHeaderSize)
+		binary.LittleEndian.PutUint32(record[0:], uint32(len(payload)))
+		binary.LittleEndian.PutUint32(record[4:], crc32.Checksum(payload, walCRCTable))
+		data = append(append(data, record...), payload...)
+	}
+	path := filepath.Join(t.TempDir(), "wal_1.log")
+	os.WriteFile(path, data, 0644)
+
+	lsns, err := readTestSegment(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lsns) != 3 {
+		t.Fatalf("esperado 3 registros, recebido %d", len(lsns))
+	}
+}
+
+func TestEncodeWALRecordRejectsOversizedRecord(t *testing.T) {
+	_, err := encodeWALRecord(LogEntry{Operation: "APPEND", Value: BytesValue(make([]byte, walMaxRecordSize))})
+	if !errors.Is(err, ErrRecordTooLarge) {
+		t.Fatalf("esperado ErrRecordTooLarge, recebido %v", err)
+	}
+}
+
+// Uma escrita grande demais para o WAL falha sem ser aplicada, e o servidor
+// continua reiniciando normalmente
+func TestAppendRejectsOversizedRecord(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	var ok bool
+	list.Append(AppendArgs{ListName: "l", Value: IntValue(1)}, &ok)
+	ok = false
+	err = list.Append(AppendArgs{ListName: "l", Value: BytesValue(make([]byte, 13*1024*1024))}, &ok)
+	if !errors.Is(err, ErrRecordTooLarge) || ok {
+		t.Fatalf("esperado ErrRecordTooLarge, recebido err=%v ok=%v", err, ok)
+	}
+
+	var size int
+	list.Size(SizeArgs{ListName: "l"}, &size)
+	if size != 1 {
+		t.Fatalf("o valor rejeitado não pode ser aplicado: tamanho %d", size)
+	}
+
+	config.DataDir = copyDataDir(t, config.DataDir)
+	recovered, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered.Close()
+}
@@ -0,0 +1,163 @@
+package remotelist
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// copyDataDir copia os arquivos do diretório de dados: é o que estaria em disco
+// se o processo morresse nesse instante (sem Close e sem snapshot final)
+func copyDataDir(t *testing.T, src string) string {
+	t.Helper()
+	dst := t.TempDir()
+	files, err := os.ReadDir(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(src, file.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dst, file.Name()), data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dst
+}
+
+// Escritas confirmadas enquanto snapshots são criados não podem se perder: o
+// snapshot só descarta segmentos do WAL cobertos por ele
+func TestCrashRecoveryKeepsAcknowledgedWrites(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	config.SnapshotRetention = 2
+
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	const writers, appends = 8, 300
+	acknowledged := make([][]Value, writers)
+
+	var writersWG sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		writersWG.Add(1)
+		go func(w int) {
+			defer writersWG.Done()
+			name := fmt.Sprintf("lista_%d", w)
+			for i := 0; i < appends; i++ {
+				var ok bool
+				err := list.Append(AppendArgs{ListName: name, Value: IntValue(i)}, &ok)
+				if err == nil && ok {
+					acknowledged[w] = append(acknowledged[w], IntValue(i))
+				}
+			}
+		}(w)
+	}
+
+	done := make(chan struct{})
+	snapshotsDone := make(chan int)
+	go func() {
+		snapshots := 0
+		for {
+			select {
+			case <-done:
+				snapshotsDone <- snapshots
+				return
+			default:
+			}
+			err := list.createSnapshot()
+			if err != nil {
+				t.Errorf("createSnapshot: %v", err)
+			}
+			snapshots++
+		}
+	}()
+
+	writersWG.Wait()
+	close(done)
+	if snapshots := <-snapshotsDone; snapshots < 2 {
+		t.Fatalf("esperado snapshots durante as escritas, houve %d", snapshots)
+	}
+
+	config.DataDir = copyDataDir(t, config.DataDir)
+	recovered, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { recovered.Close() })
+
+	for w := 0; w < writers; w++ {
+		if len(acknowledged[w]) != appends {
+			t.Fatalf("lista_%d: %d de %d escritas confirmadas", w, len(acknowledged[w]), appends)
+		}
+		var reply RangeReply
+		err := recovered.GetRange(GetRangeArgs{ListName: fmt.Sprintf("lista_%d", w), ToEnd: true}, &reply)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(reply.Values, acknowledged[w]) {
+			t.Fatalf("lista_%d: recuperados %d valores, confirmados %d", w, len(reply.Values), len(acknowledged[w]))
+		}
+	}
+}
+
+// Um escritor sozinho não espera o atraso do group commit: não há outras escritas
+// para entrar no lote
+func TestSequentialWritesSkipBatchDelay(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	config.WALBatchDelay = time.Second
+
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		var ok bool
+		err := list.Append(AppendArgs{ListName: "l", Value: IntValue(i)}, &ok)
+		if err != nil || !ok {
+			t.Fatalf("Append: err=%v ok=%v", err, ok)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= config.WALBatchDelay {
+		t.Fatalf("5 escritas sequenciais levaram %v: o commit esperou o atraso do lote", elapsed)
+	}
+}
+
+// O WAL é mantido a partir do menor LSN entre os snapshots retidos, mesmo que ele
+// não seja o do snapshot com o nome mais antigo
+func TestOldestRetainedSnapshotLSNUsesMinimumLSN(t *testing.T) {
+	config := DefaultConfig()
+	config.DataDir = t.TempDir()
+	list, err := NewRemoteListWithConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { list.Close() })
+
+	snapshots := map[string]uint64{"snapshot_100.json": 80, "snapshot_200.json": 50, "snapshot_300.json": 120}
+	for name, lsn := range snapshots {
+		data, _ := json.Marshal(SnapshotData{LSN: lsn})
+		os.WriteFile(filepath.Join(config.DataDir, name), data, 0644)
+	}
+	os.WriteFile(filepath.Join(config.DataDir, "snapshot_050.json"), []byte("{corrompido"), 0644)
+
+	lsn, err := list.oldestRetainedSnapshotLSN()
+	if err != nil || lsn != 50 {
+		t.Fatalf("esperado LSN 50, recebido %d (err=%v)", lsn, err)
+	}
+}